@@ -29,7 +29,10 @@ func (ga *GradeAssessment) AssessGrade(threshold string, details []filter.GradeD
 	passed := true
 	ga.ViolationDetails = []filter.GradeDetails{} // Reset violations
 	for _, detail := range details {
-		if ga.Calculator.GradeNumericalValue(detail.Grade) < ga.Calculator.GradeNumericalValue(threshold) {
+		if detail.Unassessed {
+			continue // No grade to compare; the completeness assessment covers these
+		}
+		if detail.Score < ga.Calculator.GradeNumericalValue(effectiveThresholdGrade(detail, threshold)) { // Score is already on the threshold's scale
 			passed = false
 			ga.ViolationDetails = append(ga.ViolationDetails, detail)
 		}
@@ -38,4 +41,13 @@ func (ga *GradeAssessment) AssessGrade(threshold string, details []filter.GradeD
 		ga.Reporter.Report(ga.ViolationDetails)
 	}
 	return passed
-}
\ No newline at end of file
+}
+
+// effectiveThresholdGrade is the grade the detail is held to: one the thresholds in
+// config.json set for its tool and path, or else the assessment's threshold.
+func effectiveThresholdGrade(detail filter.GradeDetails, fallback string) string {
+	if detail.ThresholdGrade != "" {
+		return detail.ThresholdGrade
+	}
+	return fallback
+}
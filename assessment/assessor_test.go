@@ -0,0 +1,61 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"testing"
+)
+
+func TestGradeAssessmentAssessGrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		threshold  string
+		details    []filter.GradeDetails
+		passed     bool
+		violations []string
+	}{
+		{
+			name:      "at and above the threshold",
+			threshold: "B",
+			details:   []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Grade: "B", Score: 8}, {FileName: "b.go", Tool: "SOLID", Grade: "A+", Score: 12}},
+			passed:    true,
+		},
+		{
+			name:      "each file/tool pair is assessed",
+			threshold: "B",
+			details: []filter.GradeDetails{
+				{FileName: "a.go", Tool: "SOLID", Grade: "A", Score: 11},
+				{FileName: "a.go", Tool: "OWASP-TOP-10", Grade: "B-", Score: 7},
+				{FileName: "b.go", Tool: "SOLID", Grade: "F", Score: 0},
+			},
+			violations: []string{"a.go", "b.go"},
+		},
+		{
+			name:      "the resolved threshold wins over the assessment's",
+			threshold: "B",
+			details: []filter.GradeDetails{
+				{FileName: "legacy/a.go", Tool: "SOLID", Grade: "C", Score: 5, ThresholdGrade: "C"},
+				{FileName: "b.go", Tool: "SOLID", Grade: "B", Score: 8, ThresholdGrade: "A"},
+			},
+			violations: []string{"b.go"},
+		},
+		{
+			name:      "unassessed pairs are left to the completeness gate",
+			threshold: "B",
+			details:   []filter.GradeDetails{filter.NewUnassessedGradeDetails("a.go", "SOLID")},
+			passed:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			assessment := NewGradeAssessment(filter.NewGradeScales(nil), reporter)
+
+			if passed := assessment.AssessGrade(tt.threshold, tt.details); passed != tt.passed {
+				t.Errorf("AssessGrade = %v, expected %v", passed, tt.passed)
+			}
+			if !violatedBy(reporter.violations, tt.violations) {
+				t.Errorf("violations = %+v, expected %v", reporter.violations, tt.violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"fmt"
+	"os"
+)
+
+// BaselineAssessable interface for assessing grades and coverage against a baseline
+type BaselineAssessable interface {
+	AssessGrades(details []filter.GradeDetails) bool
+	AssessCoverage(details []filter.GradeDetails) bool
+}
+
+// BaselineAssessment fails when a file's grade for a tool, or the average coverage, drops
+// below the baseline. Files and tools the baseline does not know cannot fail it.
+type BaselineAssessment struct {
+	Baseline         *filter.Baseline
+	Weigher          filter.CoverageWeigher
+	Reporter         ViolationReporter
+	ViolationDetails []filter.GradeDetails
+}
+
+// NewBaselineAssessment creates a new BaselineAssessment instance
+func NewBaselineAssessment(baseline *filter.Baseline, weigher filter.CoverageWeigher, reporter ViolationReporter) BaselineAssessable {
+	return &BaselineAssessment{Baseline: baseline, Weigher: weigher, Reporter: reporter}
+}
+
+// AssessGrades passes when no grade is below its baseline grade
+func (ba *BaselineAssessment) AssessGrades(details []filter.GradeDetails) bool {
+	ba.ViolationDetails = []filter.GradeDetails{} // Reset violations
+	for _, detail := range details {
+		if detail.Unassessed {
+			continue // The coverage check counts these
+		}
+		if grade, ok := ba.Baseline.Grade(detail.FileName, detail.Tool); ok && detail.Score < grade.Score {
+			ba.ViolationDetails = append(ba.ViolationDetails, detail)
+		}
+	}
+	if len(ba.ViolationDetails) > 0 {
+		ba.Reporter.Report(ba.ViolationDetails)
+		return false
+	}
+	return true
+}
+
+// AssessCoverage passes when the average coverage, averaged like CoverageAssessment does,
+// is at least the baseline coverage. When it is not, the violations are the grades that
+// pulled it down: those below their baseline grade, and those new to the baseline with
+// less than the baseline coverage.
+func (ba *BaselineAssessment) AssessCoverage(details []filter.GradeDetails) bool {
+	ba.ViolationDetails = []filter.GradeDetails{} // Reset violations
+	coverage := NewAverages(details, ba.Weigher).Overall
+	fmt.Fprintf(os.Stderr, "Average coverage: %.2f%% (baseline %.2f%%)\n", coverage, ba.Baseline.Coverage)
+	if !ba.Baseline.CoverageDropped(coverage) {
+		return true
+	}
+
+	for _, detail := range details {
+		grade, ok := ba.Baseline.Grade(detail.FileName, detail.Tool)
+		if ok && !detail.Unassessed && detail.Score < grade.Score {
+			ba.ViolationDetails = append(ba.ViolationDetails, detail)
+		} else if !ok && float64(detail.Coverage) < ba.Baseline.Coverage {
+			ba.ViolationDetails = append(ba.ViolationDetails, detail)
+		}
+	}
+	ba.Reporter.Report(ba.ViolationDetails)
+	reportCoverage(ba.Reporter, coverage, ba.Baseline.Coverage)
+	return false
+}
@@ -0,0 +1,133 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"testing"
+)
+
+// recordingReporter keeps what an assessment reports.
+type recordingReporter struct {
+	violations []filter.GradeDetails
+	average    float64
+	required   float64
+}
+
+func (r *recordingReporter) Report(violations []filter.GradeDetails) {
+	r.violations = append(r.violations, violations...)
+}
+
+func (r *recordingReporter) ReportCoverage(average float64, required float64) {
+	r.average, r.required = average, required
+}
+
+func ratchetBaseline() *filter.Baseline {
+	return &filter.Baseline{
+		Coverage: 75,
+		Grades: []filter.BaselineGrade{
+			{Path: "a.go", Tool: "SOLID", Grade: "B", Score: 8},
+			{Path: "b.go", Tool: "SOLID", Grade: "C", Score: 5},
+		},
+	}
+}
+
+// violatedBy reports whether the violations are of exactly the files, in order.
+func violatedBy(violations []filter.GradeDetails, files []string) bool {
+	if len(violations) != len(files) {
+		return false
+	}
+	for i, violation := range violations {
+		if violation.FileName != files[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBaselineAssessmentAssessGrades(t *testing.T) {
+	tests := []struct {
+		name       string
+		details    []filter.GradeDetails
+		passed     bool
+		violations []string
+	}{
+		{
+			name:    "grades kept",
+			details: []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Score: 8}, {FileName: "b.go", Tool: "SOLID", Score: 6}},
+			passed:  true,
+		},
+		{
+			name:       "grade dropped",
+			details:    []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Score: 7}, {FileName: "b.go", Tool: "SOLID", Score: 5}},
+			violations: []string{"a.go"},
+		},
+		{
+			name:    "tool names are case-insensitive",
+			details: []filter.GradeDetails{{FileName: "a.go", Tool: "solid", Score: 8}},
+			passed:  true,
+		},
+		{
+			name:    "pairs new to the baseline cannot fail",
+			details: []filter.GradeDetails{{FileName: "c.go", Tool: "SOLID", Score: 0}, {FileName: "a.go", Tool: "OWASP-TOP-10", Score: 0}},
+			passed:  true,
+		},
+		{
+			name:    "unassessed pairs are left to coverage",
+			details: []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Unassessed: true}},
+			passed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			assessment := NewBaselineAssessment(ratchetBaseline(), filter.NewUniformWeigher(), reporter)
+
+			if passed := assessment.AssessGrades(tt.details); passed != tt.passed {
+				t.Errorf("AssessGrades = %v, expected %v", passed, tt.passed)
+			}
+			if !violatedBy(reporter.violations, tt.violations) {
+				t.Errorf("violations = %+v, expected %v", reporter.violations, tt.violations)
+			}
+		})
+	}
+}
+
+func TestBaselineAssessmentAssessCoverage(t *testing.T) {
+	tests := []struct {
+		name       string
+		details    []filter.GradeDetails
+		passed     bool
+		violations []string
+	}{
+		{
+			name:    "coverage kept",
+			details: []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Score: 8, Coverage: 80}, {FileName: "b.go", Tool: "SOLID", Score: 5, Coverage: 70}},
+			passed:  true,
+		},
+		{
+			name: "dropped grade and new low pair are reported",
+			details: []filter.GradeDetails{
+				{FileName: "a.go", Tool: "SOLID", Score: 6, Coverage: 60},
+				{FileName: "b.go", Tool: "SOLID", Score: 5, Coverage: 70},
+				{FileName: "c.go", Tool: "SOLID", Score: 0, Coverage: 0, Unassessed: true},
+				{FileName: "d.go", Tool: "SOLID", Score: 12, Coverage: 100},
+			},
+			violations: []string{"a.go", "c.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			assessment := NewBaselineAssessment(ratchetBaseline(), filter.NewUniformWeigher(), reporter)
+
+			if passed := assessment.AssessCoverage(tt.details); passed != tt.passed {
+				t.Errorf("AssessCoverage = %v, expected %v", passed, tt.passed)
+			}
+			if !violatedBy(reporter.violations, tt.violations) {
+				t.Errorf("violations = %+v, expected %v", reporter.violations, tt.violations)
+			}
+			if !tt.passed && (reporter.required != 75 || reporter.average >= 75) {
+				t.Errorf("reported coverage %v against %v, expected an average below the baseline 75", reporter.average, reporter.required)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+)
+
+// CollectingViolationReporter implements ViolationReporter by keeping the violations, so
+// that they can be written out in another format once all gates have run.
+type CollectingViolationReporter struct {
+	Violations []filter.GradeDetails
+	Coverage   *CoverageOutcome // The average coverage, if it was reported
+}
+
+func NewCollectingViolationReporter() *CollectingViolationReporter {
+	return &CollectingViolationReporter{Violations: []filter.GradeDetails{}}
+}
+
+func (c *CollectingViolationReporter) Report(violations []filter.GradeDetails) {
+	c.Violations = append(c.Violations, violations...)
+}
+
+func (c *CollectingViolationReporter) ReportCoverage(average float64, required float64) {
+	c.Coverage = &CoverageOutcome{Average: average, Required: required}
+}
+
+// MultiViolationReporter implements ViolationReporter by passing violations to several reporters.
+type MultiViolationReporter struct {
+	Reporters []ViolationReporter
+}
+
+func NewMultiViolationReporter(reporters ...ViolationReporter) ViolationReporter {
+	return &MultiViolationReporter{Reporters: reporters}
+}
+
+func (m *MultiViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, reporter := range m.Reporters {
+		reporter.Report(violations)
+	}
+}
+
+func (m *MultiViolationReporter) ReportCoverage(average float64, required float64) {
+	for _, reporter := range m.Reporters {
+		reportCoverage(reporter, average, required)
+	}
+}
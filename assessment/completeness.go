@@ -0,0 +1,36 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+)
+
+// CompletenessAssessable interface for assessing whether every source file was graded
+type CompletenessAssessable interface {
+	AssessCompleteness(details []filter.GradeDetails) bool
+}
+
+// CompletenessAssessment fails when any source file has no grade for a requested tool
+type CompletenessAssessment struct {
+	Reporter         ViolationReporter
+	ViolationDetails []filter.GradeDetails
+}
+
+// NewCompletenessAssessment creates a new CompletenessAssessment instance
+func NewCompletenessAssessment(reporter ViolationReporter) CompletenessAssessable {
+	return &CompletenessAssessment{Reporter: reporter}
+}
+
+// AssessCompleteness passes only when no detail is unassessed
+func (ca *CompletenessAssessment) AssessCompleteness(details []filter.GradeDetails) bool {
+	ca.ViolationDetails = []filter.GradeDetails{} // Reset violations
+	for _, detail := range details {
+		if detail.Unassessed {
+			ca.ViolationDetails = append(ca.ViolationDetails, detail)
+		}
+	}
+	if len(ca.ViolationDetails) > 0 {
+		ca.Reporter.Report(ca.ViolationDetails)
+		return false
+	}
+	return true
+}
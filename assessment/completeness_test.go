@@ -0,0 +1,29 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"testing"
+)
+
+func TestCompletenessAssessmentAssessCompleteness(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  []filter.GradeDetails
+		pass     bool
+		violated []string
+	}{
+		{"every file graded", []filter.GradeDetails{{FileName: "a.go", Grade: "A"}}, true, nil},
+		{"unassessed file", []filter.GradeDetails{{FileName: "a.go", Grade: "A"}, filter.NewUnassessedGradeDetails("b.go", "SOLID")}, false, []string{"b.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			if pass := NewCompletenessAssessment(reporter).AssessCompleteness(tt.details); pass != tt.pass {
+				t.Errorf("AssessCompleteness = %v, expected %v", pass, tt.pass)
+			}
+			if !violatedBy(reporter.violations, tt.violated) {
+				t.Errorf("reported %+v, expected %v", reporter.violations, tt.violated)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"codeleft-cli/filter"
 	"fmt"
 	"os"
+	"sort"
 )
 
 // CoverageAssessable interface for assessing code coverage
@@ -14,37 +15,70 @@ type CoverageAssessable interface {
 // CoverageAssessment handles code coverage assessment
 type CoverageAssessment struct {
 	Reporter         ViolationReporter
+	Weigher          filter.CoverageWeigher
 	ViolationDetails []filter.GradeDetails
 }
 
 // NewCoverageAssessment creates a new CoverageAssessment instance
-func NewCoverageAssessment(reporter ViolationReporter) CoverageAssessable {
+func NewCoverageAssessment(reporter ViolationReporter, weigher filter.CoverageWeigher) CoverageAssessable {
 	return &CoverageAssessment{
 		Reporter: reporter,
+		Weigher:  weigher,
 	}
 }
 
-// AssessCoverage assesses code coverage against a threshold
+// AssessCoverage assesses code coverage against a threshold. The thresholds in config.json
+// can hold some files and tools to another percentage; the average of each group of
+// details held to the same percentage must reach it. Averages are weighted by the Weigher.
 func (ca *CoverageAssessment) AssessCoverage(thresholdPercent int, details []filter.GradeDetails) bool {
-	total := 0
 	ca.ViolationDetails = []filter.GradeDetails{} // Reset violations
+	groups := make(map[int][]filter.GradeDetails) // Details keyed by the percentage they are held to
 	for _, detail := range details {
-		total += detail.Coverage
-		if detail.Coverage < thresholdPercent {
+		percent := effectiveThresholdPercent(detail, thresholdPercent)
+		groups[percent] = append(groups[percent], detail)
+		if detail.Coverage < percent {
 			ca.ViolationDetails = append(ca.ViolationDetails, detail)
 		}
 	}
 	if len(details) == 0 {
-		fmt.Println("No files to assess")
+		fmt.Fprintln(os.Stderr, "No files to assess")
 		return false
 	}
 
-	average := float32(total) / float32(len(details))
-	pass := average >= float32(thresholdPercent)
+	average := ca.averageCoverage(details)
+	pass := true
+	percents := make([]int, 0, len(groups))
+	for percent := range groups {
+		percents = append(percents, percent)
+	}
+	sort.Ints(percents)
+	for _, percent := range percents {
+		groupAverage := ca.averageCoverage(groups[percent])
+		if groupAverage < float32(percent) {
+			pass = false
+		}
+		if len(groups) > 1 {
+			fmt.Fprintf(os.Stderr, "Average coverage of %d file/tool pair(s) held to %d%%: %.2f%%\n", len(groups[percent]), percent, groupAverage)
+		}
+	}
 
 	if !pass {
 		ca.Reporter.Report(ca.ViolationDetails)
 	}
 	fmt.Fprintf(os.Stderr, "Average coverage: %.2f%%\n", average)
 	return pass
-}
\ No newline at end of file
+}
+
+// averageCoverage is the overall average of NewAverages, which the reports show as well.
+func (ca *CoverageAssessment) averageCoverage(details []filter.GradeDetails) float32 {
+	return float32(NewAverages(details, ca.Weigher).Overall)
+}
+
+// effectiveThresholdPercent is the coverage percentage the detail is held to: one the
+// thresholds in config.json set for its tool and path, or else the assessment's threshold.
+func effectiveThresholdPercent(detail filter.GradeDetails, fallback int) int {
+	if detail.ThresholdPercent != 0 {
+		return detail.ThresholdPercent
+	}
+	return fallback
+}
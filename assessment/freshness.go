@@ -0,0 +1,36 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+)
+
+// FreshnessAssessable interface for assessing whether grades still describe their files
+type FreshnessAssessable interface {
+	AssessFreshness(details []filter.GradeDetails) bool
+}
+
+// FreshnessAssessment fails when any grade was recorded for an older version of its file
+type FreshnessAssessment struct {
+	Reporter         ViolationReporter
+	ViolationDetails []filter.GradeDetails
+}
+
+// NewFreshnessAssessment creates a new FreshnessAssessment instance
+func NewFreshnessAssessment(reporter ViolationReporter) FreshnessAssessable {
+	return &FreshnessAssessment{Reporter: reporter}
+}
+
+// AssessFreshness passes only when no grade is stale
+func (fa *FreshnessAssessment) AssessFreshness(details []filter.GradeDetails) bool {
+	fa.ViolationDetails = []filter.GradeDetails{} // Reset violations
+	for _, detail := range details {
+		if detail.Stale {
+			fa.ViolationDetails = append(fa.ViolationDetails, detail)
+		}
+	}
+	if len(fa.ViolationDetails) > 0 {
+		fa.Reporter.Report(fa.ViolationDetails)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"testing"
+)
+
+func TestFreshnessAssessmentAssessFreshness(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  []filter.GradeDetails
+		pass     bool
+		violated []string
+	}{
+		{"no grades", nil, true, nil},
+		{"fresh grades", []filter.GradeDetails{{FileName: "a.go"}, {FileName: "b.go"}}, true, nil},
+		{"stale grade", []filter.GradeDetails{{FileName: "a.go"}, {FileName: "b.go", Stale: true}}, false, []string{"b.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			if pass := NewFreshnessAssessment(reporter).AssessFreshness(tt.details); pass != tt.pass {
+				t.Errorf("AssessFreshness = %v, expected %v", pass, tt.pass)
+			}
+			if !violatedBy(reporter.violations, tt.violated) {
+				t.Errorf("reported %+v, expected %v", reporter.violations, tt.violated)
+			}
+		})
+	}
+}
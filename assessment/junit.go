@@ -0,0 +1,176 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// JUnitReporter implements ViolationReporter as a JUnit XML sink. Each tool becomes a
+// testsuite and each file a testcase of it. A testcase fails when its grade is below the
+// threshold grade, when its coverage is below the threshold percentage, or when a gate
+// reported it as a violation (a stale, unassessed or missing grade). The thresholds in
+// config.json take precedence over ThresholdGrade and ThresholdPercent.
+type JUnitReporter struct {
+	ThresholdGrade   string
+	ThresholdPercent int
+	CheckCoverage    bool // Only the coverage assessment checks coverage
+	Calculator       filter.GradeCalculator
+	Violations       []filter.GradeDetails
+}
+
+func NewJUnitReporter(thresholdGrade string, thresholdPercent int, checkCoverage bool, calculator filter.GradeCalculator) *JUnitReporter {
+	return &JUnitReporter{
+		ThresholdGrade:   thresholdGrade,
+		ThresholdPercent: thresholdPercent,
+		CheckCoverage:    checkCoverage,
+		Calculator:       calculator,
+		Violations:       []filter.GradeDetails{},
+	}
+}
+
+func (j *JUnitReporter) Report(violations []filter.GradeDetails) {
+	j.Violations = append(j.Violations, violations...)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteReport writes the JUnit XML document for details to w. Violations reported for a
+// file/tool pair that details does not contain are added as testcases of their own.
+func (j *JUnitReporter) WriteReport(w io.Writer, details []filter.GradeDetails) error {
+	reported := make(map[string]filter.GradeDetails)
+	for _, violation := range j.Violations {
+		reported[junitKey(violation)] = violation
+	}
+
+	byTool := make(map[string][]filter.GradeDetails)
+	seen := make(map[string]bool)
+	for _, detail := range append(append([]filter.GradeDetails{}, details...), j.Violations...) {
+		if seen[junitKey(detail)] {
+			continue
+		}
+		seen[junitKey(detail)] = true
+		byTool[detail.Tool] = append(byTool[detail.Tool], detail)
+	}
+
+	tools := make([]string, 0, len(byTool))
+	for tool := range byTool {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	doc := junitTestSuites{Name: "codeleft", Suites: []junitTestSuite{}}
+	for _, tool := range tools {
+		toolDetails := byTool[tool]
+		sort.SliceStable(toolDetails, func(a, b int) bool { return toolDetails[a].FileName < toolDetails[b].FileName })
+
+		suite := junitTestSuite{Name: tool, Cases: []junitTestCase{}}
+		for _, detail := range toolDetails {
+			_, wasReported := reported[junitKey(detail)]
+			testCase := j.testCase(detail, wasReported)
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error writing JUnit report: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("error encoding JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// testCase builds the testcase of one file for one tool. An unassessed file is skipped
+// unless a gate or the coverage threshold fails it.
+func (j *JUnitReporter) testCase(detail filter.GradeDetails, reported bool) junitTestCase {
+	testCase := junitTestCase{Name: detail.FileName, ClassName: detail.Tool}
+
+	var reasons, types []string
+	grade := effectiveThresholdGrade(detail, j.ThresholdGrade)
+	if !detail.Unassessed && detail.Score < j.Calculator.GradeNumericalValue(grade) {
+		reasons = append(reasons, fmt.Sprintf("grade %s%s (score %d) is below threshold %s", detail.Grade, staleMarker(detail), detail.Score, grade))
+		types = append(types, "grade")
+	}
+	if percent := effectiveThresholdPercent(detail, j.ThresholdPercent); j.CheckCoverage && percent > 0 && detail.Coverage < percent {
+		reasons = append(reasons, fmt.Sprintf("coverage %d%% is below threshold %d%%", detail.Coverage, percent))
+		types = append(types, "coverage")
+	}
+	// A gate can fail a file for reasons the thresholds do not explain.
+	if detail.Unassessed && (len(reasons) > 0 || reported) {
+		reasons = append([]string{"not graded by " + detail.Tool}, reasons...)
+		types = append([]string{"unassessed"}, types...)
+	} else if detail.Stale && len(reasons) == 0 && reported {
+		reasons = append(reasons, "file changed since it was graded "+detail.Grade)
+		types = append(types, "stale")
+	}
+
+	if len(reasons) == 0 {
+		if detail.Unassessed {
+			testCase.Skipped = &junitSkipped{Message: "not graded by " + detail.Tool}
+		}
+		return testCase
+	}
+
+	message := strings.Join(reasons, "; ")
+	text := fmt.Sprintf("File: %s\nTool: %s\nGrade: %s\nScore: %d\nCoverage: %d%%", detail.FileName, detail.Tool, detail.Grade, detail.Score, detail.Coverage)
+	if detail.Review != "" {
+		message += ": " + detail.Review
+		text += "\nReview: " + detail.Review
+	}
+	testCase.Failure = &junitFailure{Message: message, Type: strings.Join(types, ","), Text: text}
+	return testCase
+}
+
+func junitKey(detail filter.GradeDetails) string {
+	return detail.FileName + "|" + strings.ToLower(detail.Tool)
+}
@@ -0,0 +1,112 @@
+package assessment
+
+import (
+	"bytes"
+	"codeleft-cli/filter"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestJUnitReporterWriteReport(t *testing.T) {
+	details := []filter.GradeDetails{
+		{FileName: `cli/a<b>&"c".go`, Tool: "SOLID", Grade: "A", Score: 11, Coverage: 120},
+		{FileName: "cli/b.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 70, Review: "Too long"},
+		{FileName: "cli/c.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 100, ThresholdGrade: "C", ThresholdPercent: 50},
+		{FileName: "cli/s.go", Tool: "OWASP-TOP-10", Grade: "B", Score: 8, Coverage: 100, Stale: true},
+		{FileName: "cli/x&y.go", Tool: "OWASP-TOP-10", Unassessed: true},
+	}
+	reporter := NewJUnitReporter("B", 80, true, filter.NewGradeScales(nil))
+	reporter.Report([]filter.GradeDetails{
+		details[3], // Failed by the freshness gate
+		{FileName: "cli/new.go", Tool: "SOLID", Unassessed: true},
+	})
+
+	var out bytes.Buffer
+	if err := reporter.WriteReport(&out, details); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+	if !strings.Contains(out.String(), `name="cli/a&lt;b&gt;&amp;&#34;c&#34;.go"`) {
+		t.Errorf("file name not escaped in:\n%s", out.String())
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding the report: %v\n%s", err, out.String())
+	}
+
+	if doc.Tests != 6 || doc.Failures != 4 || doc.Skipped != 0 || len(doc.Suites) != 2 {
+		t.Fatalf("tests %d, failures %d, skipped %d in %d suites, expected 6, 4 and 0 in 2", doc.Tests, doc.Failures, doc.Skipped, len(doc.Suites))
+	}
+	owasp, solid := doc.Suites[0], doc.Suites[1]
+	if owasp.Name != "OWASP-TOP-10" || owasp.Tests != 2 || owasp.Failures != 2 || owasp.Skipped != 0 {
+		t.Errorf("suite %s: %d tests, %d failures, %d skipped", owasp.Name, owasp.Tests, owasp.Failures, owasp.Skipped)
+	}
+	if solid.Name != "SOLID" || solid.Tests != 4 || solid.Failures != 2 || solid.Skipped != 0 {
+		t.Errorf("suite %s: %d tests, %d failures, %d skipped", solid.Name, solid.Tests, solid.Failures, solid.Skipped)
+	}
+
+	expected := map[string]struct {
+		failureType string // Empty when the testcase passes
+		message     string
+	}{
+		`cli/a<b>&"c".go`: {},
+		"cli/b.go":        {"grade,coverage", "grade C (score 5) is below threshold B; coverage 70% is below threshold 80%: Too long"},
+		"cli/c.go":        {},
+		"cli/new.go":      {"unassessed,coverage", "not graded by SOLID; coverage 0% is below threshold 80%"},
+		"cli/s.go":        {"stale", "file changed since it was graded B"},
+		"cli/x&y.go":      {"unassessed,coverage", "not graded by OWASP-TOP-10; coverage 0% is below threshold 80%"},
+	}
+	for _, suite := range doc.Suites {
+		for _, testCase := range suite.Cases {
+			want, ok := expected[testCase.Name]
+			if !ok {
+				t.Errorf("unexpected testcase %q", testCase.Name)
+				continue
+			}
+			if testCase.ClassName != suite.Name {
+				t.Errorf("%s has classname %q, expected %q", testCase.Name, testCase.ClassName, suite.Name)
+			}
+			if want.failureType == "" {
+				if testCase.Failure != nil {
+					t.Errorf("%s failed with %q, expected it to pass", testCase.Name, testCase.Failure.Message)
+				}
+				continue
+			}
+			if testCase.Failure == nil {
+				t.Errorf("%s passed, expected a %s failure", testCase.Name, want.failureType)
+				continue
+			}
+			if testCase.Failure.Type != want.failureType || testCase.Failure.Message != want.message {
+				t.Errorf("%s failed with %s %q, expected %s %q", testCase.Name, testCase.Failure.Type, testCase.Failure.Message, want.failureType, want.message)
+			}
+		}
+	}
+}
+
+func TestJUnitReporterUnassessed(t *testing.T) {
+	details := []filter.GradeDetails{
+		{FileName: "cli/x.go", Tool: "SOLID", Unassessed: true},
+		{FileName: "cli/y.go", Tool: "SOLID", Unassessed: true},
+	}
+	reporter := NewJUnitReporter("B", 80, false, filter.NewGradeScales(nil))
+	reporter.Report(details[1:]) // Failed by the unassessed gate
+
+	var out bytes.Buffer
+	if err := reporter.WriteReport(&out, details); err != nil {
+		t.Fatalf("WriteReport: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding the report: %v", err)
+	}
+	if doc.Tests != 2 || doc.Failures != 1 || doc.Skipped != 1 {
+		t.Fatalf("tests %d, failures %d, skipped %d, expected one failed and one skipped", doc.Tests, doc.Failures, doc.Skipped)
+	}
+	skipped, failed := doc.Suites[0].Cases[0], doc.Suites[0].Cases[1]
+	if skipped.Skipped == nil || skipped.Skipped.Message != "not graded by SOLID" {
+		t.Errorf("cli/x.go skipped with %+v, expected it skipped as not graded", skipped.Skipped)
+	}
+	if failed.Failure == nil || failed.Failure.Type != "unassessed" || failed.Failure.Message != "not graded by SOLID" {
+		t.Errorf("cli/y.go failed with %+v, expected an unassessed failure", failed.Failure)
+	}
+}
@@ -0,0 +1,127 @@
+package assessment
+
+import (
+	"codeleft-cli/filter"
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// ResultSchemaVersion identifies the layout of Result. It is bumped whenever a field is
+// renamed, removed or changes meaning; adding a field does not bump it.
+// The layout is described by schema/assessment-result.schema.json.
+// Version 2: averages are weighted by the weighting strategy that Weighting records.
+const ResultSchemaVersion = 2
+
+// Result is the machine-readable outcome of an assessment, written by "assess --format json".
+type Result struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	CLIVersion    string        `json:"cliVersion"`
+	Command       string        `json:"command"`
+	Passed        bool          `json:"passed"`
+	Threshold     Threshold     `json:"threshold"`
+	Tools         []string      `json:"tools"`
+	Gates         []GateOutcome `json:"gates"`
+	Grades        []GradeEntry  `json:"grades"`
+	Averages      Averages      `json:"averages"`
+	Weighting     string        `json:"weighting"` // The weighting strategy of the averages and the coverage gates
+}
+
+// Threshold holds the thresholds the assessment ran with.
+type Threshold struct {
+	Grade   string `json:"grade"`
+	Percent int    `json:"percent"`
+}
+
+// GateOutcome is the pass/fail result of a single gate and the entries that failed it.
+type GateOutcome struct {
+	Name       string           `json:"name"`
+	Passed     bool             `json:"passed"`
+	Error      string           `json:"error,omitempty"`
+	Violations []GradeEntry     `json:"violations"`
+	Coverage   *CoverageOutcome `json:"coverage,omitempty"` // Set by gates that failed on the average coverage
+}
+
+// CoverageOutcome is an average coverage and the coverage it had to reach.
+type CoverageOutcome struct {
+	Average  float64 `json:"average"`
+	Required float64 `json:"required"`
+}
+
+// GradeEntry is the latest grade of one file for one tool, and the threshold it is held
+// to: the thresholds in config.json resolved for its tool and path, else the Threshold.
+type GradeEntry struct {
+	File             string `json:"file"`
+	Tool             string `json:"tool"`
+	Grade            string `json:"grade"`
+	Score            int    `json:"score"`
+	Coverage         int    `json:"coverage"`
+	Stale            bool   `json:"stale"`
+	Unassessed       bool   `json:"unassessed"`
+	ThresholdGrade   string `json:"thresholdGrade"`
+	ThresholdPercent int    `json:"thresholdPercent"`
+}
+
+// Averages holds the average coverage per tool and over every file/tool entry, each entry
+// weighted by the result's weighting strategy.
+type Averages struct {
+	Tools   map[string]float64 `json:"tools"`
+	Overall float64            `json:"overall"`
+}
+
+// NewGradeEntries converts grade details into result entries, ordered by file and tool.
+func NewGradeEntries(details []filter.GradeDetails) []GradeEntry {
+	entries := make([]GradeEntry, 0, len(details))
+	for _, detail := range details {
+		entries = append(entries, GradeEntry{
+			File:             detail.FileName,
+			Tool:             detail.Tool,
+			Grade:            detail.Grade,
+			Score:            detail.Score,
+			Coverage:         detail.Coverage,
+			Stale:            detail.Stale,
+			Unassessed:       detail.Unassessed,
+			ThresholdGrade:   detail.ThresholdGrade,
+			ThresholdPercent: detail.ThresholdPercent,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].File != entries[j].File {
+			return entries[i].File < entries[j].File
+		}
+		return entries[i].Tool < entries[j].Tool
+	})
+	return entries
+}
+
+// NewAverages averages coverage for CoverageAssessment, the baseline and the reports: every
+// file/tool entry counts by its weight.
+func NewAverages(details []filter.GradeDetails, weigher filter.CoverageWeigher) Averages {
+	sums := make(map[string]float64)
+	weights := make(map[string]float64)
+	total := 0.0
+	totalWeight := 0.0
+	for _, detail := range details {
+		weight := filter.DetailWeight(weigher, detail)
+		sums[detail.Tool] += weight * float64(detail.Coverage)
+		weights[detail.Tool] += weight
+		total += weight * float64(detail.Coverage)
+		totalWeight += weight
+	}
+
+	averages := Averages{Tools: make(map[string]float64)}
+	for tool, sum := range sums {
+		averages.Tools[tool] = sum / weights[tool]
+	}
+	if len(details) > 0 {
+		averages.Overall = total / totalWeight
+	}
+	return averages
+}
+
+// WriteResult encodes the result as indented JSON.
+func WriteResult(w io.Writer, result Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
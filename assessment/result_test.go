@@ -0,0 +1,86 @@
+package assessment
+
+import (
+	"bytes"
+	"codeleft-cli/filter"
+	"codeleft-cli/schema"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestNewGradeEntries(t *testing.T) {
+	details := []filter.GradeDetails{
+		{FileName: "b.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 70, ThresholdGrade: "B"},
+		{FileName: "a.go", Tool: "SOLID", Grade: "A", Score: 11, Coverage: 100, Stale: true, ThresholdGrade: "B"},
+		filter.NewUnassessedGradeDetails("a.go", "OWASP-TOP-10"),
+	}
+	expected := []GradeEntry{
+		{File: "a.go", Tool: "OWASP-TOP-10", Unassessed: true},
+		{File: "a.go", Tool: "SOLID", Grade: "A", Score: 11, Coverage: 100, Stale: true, ThresholdGrade: "B"},
+		{File: "b.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 70, ThresholdGrade: "B"},
+	}
+	if entries := NewGradeEntries(details); !reflect.DeepEqual(entries, expected) {
+		t.Errorf("NewGradeEntries = %+v, expected %+v", entries, expected)
+	}
+	if entries := NewGradeEntries(nil); entries == nil || len(entries) != 0 {
+		t.Errorf("NewGradeEntries(nil) = %#v, expected an empty list that encodes as []", entries)
+	}
+}
+
+func TestNewAverages(t *testing.T) {
+	details := []filter.GradeDetails{
+		{FileName: "a.go", Tool: "SOLID", Coverage: 100},
+		{FileName: "b.go", Tool: "SOLID", Coverage: 50},
+		{FileName: "a.go", Tool: "OWASP-TOP-10", Coverage: 30},
+	}
+	averages := NewAverages(details, filter.NewUniformWeigher())
+	expected := Averages{Tools: map[string]float64{"SOLID": 75, "OWASP-TOP-10": 30}, Overall: 60}
+	if !reflect.DeepEqual(averages, expected) {
+		t.Errorf("NewAverages = %+v, expected %+v", averages, expected)
+	}
+
+	if empty := NewAverages(nil, filter.NewUniformWeigher()); empty.Overall != 0 || len(empty.Tools) != 0 {
+		t.Errorf("NewAverages(nil) = %+v, expected no averages", empty)
+	}
+}
+
+func TestWriteResultMatchesSchema(t *testing.T) {
+	document, err := os.ReadFile("../schema/assessment-result.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator, err := schema.NewSchemaValidator(document)
+	if err != nil {
+		t.Fatalf("NewSchemaValidator: %v", err)
+	}
+
+	details := []filter.GradeDetails{
+		{FileName: "a.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 70, ThresholdGrade: "B"},
+		filter.NewUnassessedGradeDetails("b.go", "SOLID"),
+	}
+	violations := NewGradeEntries(details[:1])
+	result := Result{
+		SchemaVersion: ResultSchemaVersion,
+		CLIVersion:    "test",
+		Command:       "assess coverage",
+		Threshold:     Threshold{Grade: "B", Percent: 80},
+		Tools:         []string{"SOLID"},
+		Gates: []GateOutcome{
+			{Name: "grade", Violations: violations},
+			{Name: "coverage", Violations: violations, Coverage: &CoverageOutcome{Average: 35, Required: 80}},
+			{Name: "unassessed", Violations: []GradeEntry{}},
+		},
+		Grades:    NewGradeEntries(details),
+		Averages:  NewAverages(details, filter.NewUniformWeigher()),
+		Weighting: "uniform",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResult(&buf, result); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	if problems := validator.Validate(buf.Bytes()); len(problems) > 0 {
+		t.Errorf("result does not match the schema: %v\n%s", problems, buf.String())
+	}
+}
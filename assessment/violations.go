@@ -10,6 +10,19 @@ type ViolationReporter interface {
 	Report(violations []filter.GradeDetails)
 }
 
+// CoverageViolationReporter is implemented by reporters that also report an average
+// coverage that fell below the coverage it was required to reach.
+type CoverageViolationReporter interface {
+	ReportCoverage(average float64, required float64)
+}
+
+// reportCoverage passes the average coverage to reporter if it takes it.
+func reportCoverage(reporter ViolationReporter, average float64, required float64) {
+	if coverageReporter, ok := reporter.(CoverageViolationReporter); ok {
+		coverageReporter.ReportCoverage(average, required)
+	}
+}
+
 // ConsoleViolationReporter implements ViolationReporter and prints violations to the console
 type ConsoleViolationReporter struct{}
 
@@ -19,6 +32,104 @@ func NewConsoleViolationReporter() ViolationReporter {
 
 func (c *ConsoleViolationReporter) Report(violations []filter.GradeDetails) {
 	for _, v := range violations {
-		fmt.Printf("Violation: File: %s, Grade: %s, Coverage: %d\n", v.FileName, v.Grade, v.Coverage)
+		grade := v.Grade
+		if v.Unassessed {
+			grade = "unassessed (" + v.Tool + ")"
+		}
+		fmt.Printf("Violation: File: %s, Grade: %s, Coverage: %d\n", v.FileName, grade, v.Coverage)
+	}
+}
+
+// ConsoleGradeViolationReporter implements ViolationReporter for the grade gate.
+// Each line names the tool as well as the file, since a file can fail one tool and pass another.
+type ConsoleGradeViolationReporter struct {
+	ThresholdGrade string
+}
+
+func NewConsoleGradeViolationReporter(thresholdGrade string) ViolationReporter {
+	return &ConsoleGradeViolationReporter{ThresholdGrade: thresholdGrade}
+}
+
+func (c *ConsoleGradeViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, v := range violations {
+		fmt.Printf("Grade Violation: File: %s, Tool: %s, Grade: %s%s, Threshold: %s\n", v.FileName, v.Tool, v.Grade, staleMarker(v), effectiveThresholdGrade(v, c.ThresholdGrade))
+	}
+}
+
+// ConsoleStaleViolationReporter implements ViolationReporter for grades recorded against an
+// older version of their file.
+type ConsoleStaleViolationReporter struct{}
+
+func NewConsoleStaleViolationReporter() ViolationReporter {
+	return &ConsoleStaleViolationReporter{}
+}
+
+func (c *ConsoleStaleViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, v := range violations {
+		fmt.Printf("Stale Grade: File: %s, Tool: %s, Grade: %s (file changed since it was graded)\n", v.FileName, v.Tool, v.Grade)
+	}
+}
+
+// ConsoleBaselineViolationReporter implements ViolationReporter for grades that dropped
+// below the baseline.
+type ConsoleBaselineViolationReporter struct {
+	Baseline *filter.Baseline
+}
+
+func NewConsoleBaselineViolationReporter(baseline *filter.Baseline) ViolationReporter {
+	return &ConsoleBaselineViolationReporter{Baseline: baseline}
+}
+
+func (c *ConsoleBaselineViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, v := range violations {
+		if baseline, ok := c.Baseline.Grade(v.FileName, v.Tool); ok {
+			fmt.Printf("Baseline Violation: File: %s, Tool: %s, Grade: %s%s, Baseline: %s\n", v.FileName, v.Tool, v.Grade, staleMarker(v), baseline.Grade)
+		} else {
+			fmt.Printf("Baseline Violation: File: %s, Tool: %s, Coverage: %d, Baseline coverage: %.2f\n", v.FileName, v.Tool, v.Coverage, c.Baseline.Coverage)
+		}
+	}
+}
+
+func (c *ConsoleBaselineViolationReporter) ReportCoverage(average float64, required float64) {
+	fmt.Printf("Baseline Violation: Average coverage: %.2f%%, Baseline: %.2f%%\n", average, required)
+}
+
+// ConsoleUnassessedViolationReporter implements ViolationReporter for source files that a
+// tool never graded.
+type ConsoleUnassessedViolationReporter struct{}
+
+func NewConsoleUnassessedViolationReporter() ViolationReporter {
+	return &ConsoleUnassessedViolationReporter{}
+}
+
+func (c *ConsoleUnassessedViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, v := range violations {
+		fmt.Printf("Unassessed: File: %s, Tool: %s\n", v.FileName, v.Tool)
+	}
+}
+
+// ConsoleMissingGradeViolationReporter implements ViolationReporter for changed files that
+// lack a fresh grade in -changed-since mode.
+type ConsoleMissingGradeViolationReporter struct{}
+
+func NewConsoleMissingGradeViolationReporter() ViolationReporter {
+	return &ConsoleMissingGradeViolationReporter{}
+}
+
+func (c *ConsoleMissingGradeViolationReporter) Report(violations []filter.GradeDetails) {
+	for _, v := range violations {
+		reason := "not graded"
+		if v.Stale {
+			reason = "changed since graded " + v.Grade
+		}
+		fmt.Printf("Missing Grade: File: %s, Tool: %s, Reason: %s\n", v.FileName, v.Tool, reason)
+	}
+}
+
+// staleMarker flags a grade that no longer matches its file.
+func staleMarker(detail filter.GradeDetails) string {
+	if detail.Stale {
+		return " (stale)"
 	}
+	return ""
 }
@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"codeleft-cli/assessment"
+	"codeleft-cli/filter"
+	"codeleft-cli/read"
+	"codeleft-cli/write"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Output formats of the assess commands.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// assessGradeCommand implements "assess grade".
+type assessGradeCommand struct {
+	version string
+}
+
+func (c *assessGradeCommand) Name() string { return "grade" }
+
+func (c *assessGradeCommand) Synopsis() string {
+	return "Fail when any file's grade for a tool is below the threshold grade."
+}
+
+func (c *assessGradeCommand) Run(args []string) int {
+	var opts Options
+	var format, junitPath string
+	fs := newFlagSet("assess grade", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	bindFormatFlag(fs, &format)
+	bindJUnitFlag(fs, &junitPath)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if err := validateFormat(format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	gates := newGateRunner(format == formatText, ws)
+	gates.attachJUnit(junitPath, opts.ThresholdGrade, 0, false)
+	gates.gradeGate(opts, gradeDetails)
+	gates.workspaceGates(opts, ws, gradeDetails)
+	return gates.finish(format, c.version, "assess grade", opts, gradeDetails)
+}
+
+// assessCoverageCommand implements "assess coverage".
+type assessCoverageCommand struct {
+	version string
+}
+
+func (c *assessCoverageCommand) Name() string { return "coverage" }
+
+func (c *assessCoverageCommand) Synopsis() string {
+	return "Fail when the average coverage is below the threshold percentage."
+}
+
+func (c *assessCoverageCommand) Run(args []string) int {
+	var opts Options
+	var format, junitPath string
+	fs := newFlagSet("assess coverage", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	opts.bindCoverageFlags(fs)
+	bindFormatFlag(fs, &format)
+	bindJUnitFlag(fs, &junitPath)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if err := validateFormat(format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	gates := newGateRunner(format == formatText, ws)
+	gates.attachJUnit(junitPath, opts.ThresholdGrade, opts.ThresholdPercent, true)
+	gates.coverageGate(opts, gradeDetails)
+	gates.workspaceGates(opts, ws, gradeDetails)
+	return gates.finish(format, c.version, "assess coverage", opts, gradeDetails)
+}
+
+// assessRatchetCommand implements "assess ratchet".
+type assessRatchetCommand struct {
+	version string
+}
+
+func (c *assessRatchetCommand) Name() string { return "ratchet" }
+
+func (c *assessRatchetCommand) Synopsis() string {
+	return "Fail when a grade or the average coverage drops below .codeLeft/baseline.json."
+}
+
+func (c *assessRatchetCommand) Run(args []string) int {
+	var opts Options
+	var format string
+	var updateBaseline bool
+	fs := newFlagSet("assess ratchet", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	bindFormatFlag(fs, &format)
+	fs.BoolVar(&updateBaseline, "update-baseline", false, "Raise the baseline to every grade and coverage that improved on it.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if err := validateFormat(format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if err := opts.validateWholeRepository(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	// Coverage only compares with the baseline's when it is calculated the same way, so the
+	// baseline's threshold grade and tools take the place of config.json's defaults.
+	explicitGrade, explicitTools := opts.ThresholdGrade != "", opts.Tools != ""
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	baseline, err := read.NewBaselineReader(ws.BaselinePath()).ReadBaseline()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if !explicitGrade && baseline.ThresholdGrade != "" {
+		opts.ThresholdGrade = baseline.ThresholdGrade
+		fmt.Fprintf(os.Stderr, "Using threshold grade from baseline.json: %s\n", baseline.ThresholdGrade)
+	} else if baseline.ThresholdGrade != "" && baseline.ThresholdGrade != opts.ThresholdGrade {
+		fmt.Fprintf(os.Stderr, "Warning: the baseline's coverage was calculated against threshold grade %s, not %s\n", baseline.ThresholdGrade, opts.ThresholdGrade)
+	}
+	weighting := baseline.Weighting
+	if weighting == "" {
+		weighting = filter.WeightingStrategyUniform
+	}
+	if weighting != ws.WeightingStrategy() {
+		fmt.Fprintf(os.Stderr, "Warning: the baseline's coverage was averaged with the %s weighting, not %s; run 'codeleft-cli baseline' to rebuild it\n", weighting, ws.WeightingStrategy())
+	}
+	if !explicitTools && len(baseline.Tools) > 0 {
+		opts.Tools = strings.Join(baseline.Tools, ",")
+		fmt.Fprintf(os.Stderr, "Using tools from baseline.json: %s\n", strings.Join(baseline.Tools, ", "))
+	}
+
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	gates := newGateRunner(format == formatText, ws)
+	gates.baselineGates(baseline, gradeDetails)
+	gates.workspaceGates(opts, ws, gradeDetails)
+
+	if updateBaseline && baseline.Tighten(gradeDetails, assessment.NewAverages(gradeDetails, ws.Weigher).Overall) {
+		if err := write.NewBaselineWriter(ws.BaselinePath()).WriteBaseline(baseline); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing baseline: %v\n", err)
+			return ExitError
+		}
+		fmt.Fprintf(os.Stderr, "Raised the baseline in %s\n", ws.BaselinePath())
+	}
+	return gates.finish(format, c.version, "assess ratchet", opts, gradeDetails)
+}
+
+// bindFormatFlag registers the output format of an assess command.
+func bindFormatFlag(fs *flag.FlagSet, format *string) {
+	fs.StringVar(format, "format", formatText, "Output format: text, or json for a single machine-readable document on stdout.")
+}
+
+// bindJUnitFlag registers the path of the optional JUnit XML report of an assess command.
+func bindJUnitFlag(fs *flag.FlagSet, path *string) {
+	fs.StringVar(path, "junit", "", "Also write a JUnit XML report to this path, with a testsuite per tool and a testcase per file.")
+}
+
+func validateFormat(format string) error {
+	if format != formatText && format != formatJSON {
+		return fmt.Errorf("unknown -format %q: expected text or json", format)
+	}
+	return nil
+}
+
+// gateRunner runs the gates of an assessment and records the outcome of each one. Every gate
+// runs, so that machine-readable output covers all of them; the exit code is that of the
+// first gate that failed.
+type gateRunner struct {
+	console   bool                   // Print violations to stdout as each gate finds them
+	weigher   filter.CoverageWeigher // Weighs the coverage averages
+	weighting string                 // The weighting strategy of weigher
+	scales    *filter.GradeScales    // Places the threshold grades on the built-in scale
+	junit     *assessment.JUnitReporter
+	junitPath string
+	outcomes  []assessment.GateOutcome
+	exitCode  int
+}
+
+func newGateRunner(console bool, ws *workspace) *gateRunner {
+	return &gateRunner{console: console, weigher: ws.Weigher, weighting: ws.WeightingStrategy(), scales: ws.Scales, outcomes: []assessment.GateOutcome{}}
+}
+
+// run executes check with a reporter that collects its violations, with the console
+// reporter as well in text mode, and with the JUnit sink when one is attached.
+func (g *gateRunner) run(name string, failCode int, failMessage string, console assessment.ViolationReporter, check func(assessment.ViolationReporter) bool) {
+	collector := assessment.NewCollectingViolationReporter()
+	reporters := []assessment.ViolationReporter{collector}
+	if g.console {
+		reporters = append(reporters, console)
+	}
+	if g.junit != nil {
+		reporters = append(reporters, g.junit)
+	}
+	reporter := assessment.NewMultiViolationReporter(reporters...)
+
+	passed := check(reporter)
+	g.outcomes = append(g.outcomes, assessment.GateOutcome{
+		Name:       name,
+		Passed:     passed,
+		Violations: assessment.NewGradeEntries(collector.Violations),
+		Coverage:   collector.Coverage,
+	})
+	if !passed {
+		fmt.Fprintf(os.Stderr, "%s :( \n", failMessage)
+		g.fail(failCode)
+	}
+}
+
+// attachJUnit adds a JUnit XML sink that receives the violations of every gate and is
+// written to path when the assessment finishes. An empty path attaches nothing.
+func (g *gateRunner) attachJUnit(path string, thresholdGrade string, thresholdPercent int, checkCoverage bool) {
+	if path == "" {
+		return
+	}
+	g.junitPath = path
+	g.junit = assessment.NewJUnitReporter(thresholdGrade, thresholdPercent, checkCoverage, g.scales)
+}
+
+func (g *gateRunner) fail(code int) {
+	if g.exitCode == ExitOK {
+		g.exitCode = code
+	}
+}
+
+// gradeGate compares every file/tool grade with the threshold grade it resolved to.
+func (g *gateRunner) gradeGate(opts Options, gradeDetails []filter.GradeDetails) {
+	if err := opts.validateResolvedThresholdGrades(gradeDetails); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot assess grades: %v\n", err)
+		g.outcomes = append(g.outcomes, assessment.GateOutcome{Name: "grade", Error: err.Error(), Violations: []assessment.GradeEntry{}})
+		g.fail(ExitError)
+		return
+	}
+
+	g.run("grade", ExitGradeFailed, "Grade threshold failed", assessment.NewConsoleGradeViolationReporter(opts.ThresholdGrade), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewGradeAssessment(g.scales, reporter).AssessGrade(opts.ThresholdGrade, gradeDetails)
+	})
+}
+
+// coverageGate compares the average coverage with the threshold percentage.
+func (g *gateRunner) coverageGate(opts Options, gradeDetails []filter.GradeDetails) {
+	g.run("coverage", ExitCoverageFailed, "Coverage threshold failed", assessment.NewConsoleViolationReporter(), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewCoverageAssessment(reporter, g.weigher).AssessCoverage(opts.ThresholdPercent, gradeDetails)
+	})
+}
+
+// baselineGates compare every file/tool grade and the average coverage with the baseline.
+func (g *gateRunner) baselineGates(baseline *filter.Baseline, gradeDetails []filter.GradeDetails) {
+	g.run("baselineGrade", ExitGradeFailed, "Grades dropped below the baseline", assessment.NewConsoleBaselineViolationReporter(baseline), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewBaselineAssessment(baseline, g.weigher, reporter).AssessGrades(gradeDetails)
+	})
+	g.run("baselineCoverage", ExitCoverageFailed, "Coverage dropped below the baseline", assessment.NewConsoleBaselineViolationReporter(baseline), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewBaselineAssessment(baseline, g.weigher, reporter).AssessCoverage(gradeDetails)
+	})
+}
+
+// workspaceGates runs the gates enabled by the workspace's policies.
+func (g *gateRunner) workspaceGates(opts Options, ws *workspace, gradeDetails []filter.GradeDetails) {
+	g.staleGate(ws, gradeDetails)
+	g.unassessedGate(ws, gradeDetails)
+	g.changedFilesGate(opts, ws, gradeDetails)
+}
+
+// staleGate fails when the stale policy is "fail" and any grade is stale.
+func (g *gateRunner) staleGate(ws *workspace, gradeDetails []filter.GradeDetails) {
+	if ws.StalePolicy != filter.StalePolicyFail {
+		return
+	}
+	g.run("stale", ExitGradeFailed, "Stale grades found", assessment.NewConsoleStaleViolationReporter(), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewFreshnessAssessment(reporter).AssessFreshness(gradeDetails)
+	})
+}
+
+// unassessedGate fails when the unassessed policy is "fail" and any source file has no
+// grade for a requested tool.
+func (g *gateRunner) unassessedGate(ws *workspace, gradeDetails []filter.GradeDetails) {
+	if ws.Unassessed != filter.UnassessedPolicyFail {
+		return
+	}
+	g.run("unassessed", ExitGradeFailed, "Unassessed source files found", assessment.NewConsoleUnassessedViolationReporter(), func(reporter assessment.ViolationReporter) bool {
+		return assessment.NewCompletenessAssessment(reporter).AssessCompleteness(gradeDetails)
+	})
+}
+
+// changedFilesGate fails when, in -changed-since mode, a changed source file has no fresh
+// grade for one of the requested tools. Freshness follows -stale-policy, so with "off" any
+// grade counts as fresh.
+func (g *gateRunner) changedFilesGate(opts Options, ws *workspace, gradeDetails []filter.GradeDetails) {
+	if ws.ChangeSet == nil {
+		return
+	}
+	g.run("changedFiles", ExitGradeFailed, "Changed files without a fresh grade", assessment.NewConsoleMissingGradeViolationReporter(), func(reporter assessment.ViolationReporter) bool {
+		missing := filter.MissingFreshGrades(ws.RequiredChangedPaths(), opts.ToolList(), gradeDetails)
+		if len(missing) > 0 {
+			reporter.Report(missing)
+			return false
+		}
+		return true
+	})
+}
+
+// finish writes the JUnit report if one is attached and the JSON result in json mode, and
+// returns the exit code of the assessment.
+func (g *gateRunner) finish(format string, version string, command string, opts Options, gradeDetails []filter.GradeDetails) int {
+	if g.junit != nil {
+		if err := writeJUnitReport(g.junitPath, g.junit, gradeDetails); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return ExitError
+		}
+		fmt.Fprintf(os.Stderr, "JUnit report written to %s\n", g.junitPath)
+	}
+	if format == formatJSON {
+		result := assessment.Result{
+			SchemaVersion: assessment.ResultSchemaVersion,
+			CLIVersion:    version,
+			Command:       command,
+			Passed:        g.exitCode == ExitOK,
+			Threshold:     assessment.Threshold{Grade: opts.ThresholdGrade, Percent: opts.ThresholdPercent},
+			Tools:         opts.ToolList(),
+			Gates:         g.outcomes,
+			Grades:        assessment.NewGradeEntries(gradeDetails),
+			Averages:      assessment.NewAverages(gradeDetails, g.weigher),
+			Weighting:     g.weighting,
+		}
+		if err := assessment.WriteResult(os.Stdout, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing result: %v\n", err)
+			return ExitError
+		}
+	}
+
+	if g.exitCode == ExitOK {
+		fmt.Fprintf(os.Stderr, "All checks passed!\n")
+	}
+	return g.exitCode
+}
+
+func writeJUnitReport(path string, junit *assessment.JUnitReporter, gradeDetails []filter.GradeDetails) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating JUnit report: %w", err)
+	}
+	defer file.Close()
+	return junit.WriteReport(file, gradeDetails)
+}
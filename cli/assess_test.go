@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"codeleft-cli/assessment"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// emptyFileHash is the SHA-256 of the empty source files newTestProject creates.
+const emptyFileHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// hashedHistoryLine is historyLine with the hash of the file as it was graded.
+func hashedHistoryLine(path string, tool string, grade string, day int, hash string) string {
+	return strings.Replace(historyLine(path, tool, grade, day), "}", `,"hash":"`+hash+`"}`, 1)
+}
+
+func TestAssessGradeStalePolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		code   int
+		stderr string
+	}{
+		{"warn", ExitOK, "Warning: 1 grade(s) are stale"},
+		{"exclude", ExitOK, "Excluded 1 stale grade(s)"},
+		{"fail", ExitGradeFailed, "cli/a.go"},
+		{"off", ExitOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			newTestProject(t, `{"threshold": "B"}`, []string{
+				hashedHistoryLine("cli/a.go", "SOLID", "A", 1, strings.Repeat("0", 64)),
+				hashedHistoryLine("cli/b.go", "SOLID", "A", 1, strings.ToUpper(emptyFileHash)),
+			}, "cli/a.go", "cli/b.go")
+
+			var code int
+			_, stderr := captureOutput(t, func() {
+				code = (&assessGradeCommand{}).Run([]string{"-tools", "SOLID", "-stale-policy", tt.policy})
+			})
+			if code != tt.code {
+				t.Errorf("exit code %d, expected %d\n%s", code, tt.code, stderr)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("stderr does not mention %q:\n%s", tt.stderr, stderr)
+			}
+			if strings.Contains(stderr, "cli/b.go") {
+				t.Errorf("the unchanged cli/b.go was reported as stale:\n%s", stderr)
+			}
+		})
+	}
+}
+
+func TestAssessUnassessedPolicy(t *testing.T) {
+	config := `{"threshold": "B", "ignore": {"patterns": ["generated/"]}}`
+	history := []string{historyLine("cli/a.go", "SOLID", "A", 1)}
+	files := []string{"cli/a.go", "cli/b.go", "generated/c.go", "vendor/d.go"}
+	coverage := []string{"-threshold-percent", "80"}
+	tests := []struct {
+		name   string
+		run    func(args []string) int
+		args   []string
+		policy string
+		code   int
+	}{
+		{"grade off", (&assessGradeCommand{}).Run, nil, "off", ExitOK},
+		{"grade zero", (&assessGradeCommand{}).Run, nil, "zero", ExitOK},
+		{"grade fail", (&assessGradeCommand{}).Run, nil, "fail", ExitGradeFailed},
+		{"coverage off", (&assessCoverageCommand{}).Run, coverage, "off", ExitOK},
+		{"coverage zero", (&assessCoverageCommand{}).Run, coverage, "zero", ExitCoverageFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProject(t, config, history, files...)
+
+			var code int
+			stdout, stderr := captureOutput(t, func() {
+				code = tt.run(append([]string{"-tools", "SOLID", "-unassessed", tt.policy}, tt.args...))
+			})
+			if code != tt.code {
+				t.Errorf("exit code %d, expected %d\n%s%s", code, tt.code, stdout, stderr)
+			}
+			found := strings.Contains(stderr, "Found 1 unassessed file/tool pair(s) among 2 source file(s)")
+			if found != (tt.policy != "off") {
+				t.Errorf("unexpected unassessed summary for policy %s:\n%s", tt.policy, stderr)
+			}
+			if tt.policy == "fail" && !strings.Contains(stdout+stderr, "cli/b.go") {
+				t.Errorf("the unassessed cli/b.go was not reported:\n%s%s", stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestAssessGradeJSONFormat(t *testing.T) {
+	newTestProject(t, `{"threshold": "B"}`, []string{
+		historyLine("cli/a.go", "SOLID", "A", 1),
+		historyLine("cli/b.go", "SOLID", "C", 1),
+	}, "cli/a.go", "cli/b.go")
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = (&assessGradeCommand{version: "1.2.3"}).Run([]string{"-tools", "SOLID", "-format", "json"})
+	})
+	if code != ExitGradeFailed {
+		t.Errorf("exit code %d, expected %d\n%s", code, ExitGradeFailed, stderr)
+	}
+
+	// The document is the only thing on stdout; the violations are not printed as text.
+	var result assessment.Result
+	decoder := json.NewDecoder(strings.NewReader(stdout))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		t.Fatalf("stdout is not a result: %v\n%s", err, stdout)
+	}
+	if decoder.More() {
+		t.Errorf("stdout holds more than the result:\n%s", stdout)
+	}
+
+	if result.SchemaVersion != assessment.ResultSchemaVersion || result.CLIVersion != "1.2.3" || result.Command != "assess grade" || result.Passed {
+		t.Errorf("result header = %d, %q, %q, passed %v", result.SchemaVersion, result.CLIVersion, result.Command, result.Passed)
+	}
+	if result.Threshold.Grade != "B" || len(result.Grades) != 2 || result.Weighting != "uniform" {
+		t.Errorf("result = %+v, expected both grades against the config threshold B", result)
+	}
+	if len(result.Gates) == 0 || result.Gates[0].Name != "grade" || result.Gates[0].Passed {
+		t.Fatalf("gates = %+v, expected a failed grade gate first", result.Gates)
+	}
+	if violations := result.Gates[0].Violations; len(violations) != 1 || violations[0].File != "cli/b.go" {
+		t.Errorf("grade gate violations = %+v, expected cli/b.go", violations)
+	}
+}
+
+func TestAssessGradeThresholdRules(t *testing.T) {
+	newTestProject(t, `{"threshold": "B", "thresholds": [{"path": "legacy/", "grade": "D"}]}`, []string{
+		historyLine("cli/a.go", "SOLID", "A", 1),
+		historyLine("cli/b.go", "SOLID", "C", 1),
+		historyLine("legacy/c.go", "SOLID", "C", 1),
+		historyLine("cli/b.go", "SOLID", "B", 2),
+		historyLine("cli/a.go", "OWASP-TOP-10", "D", 1),
+	}, "cli/a.go", "cli/b.go", "legacy/c.go")
+
+	tests := []struct {
+		args       []string
+		code       int
+		violations []string
+	}{
+		{[]string{"-tools", "SOLID"}, ExitOK, nil},
+		{[]string{"-tools", "SOLID,OWASP-TOP-10"}, ExitGradeFailed, []string{
+			"Grade Violation: File: cli/a.go, Tool: OWASP-TOP-10, Grade: D, Threshold: B\n",
+		}},
+		{[]string{"-tools", "SOLID", "-threshold-grade", "A"}, ExitGradeFailed, []string{
+			"Grade Violation: File: cli/b.go, Tool: SOLID, Grade: B, Threshold: A\n",
+		}},
+	}
+	for _, tt := range tests {
+		var code int
+		stdout, stderr := captureOutput(t, func() { code = (&assessGradeCommand{}).Run(tt.args) })
+		if code != tt.code {
+			t.Errorf("assess grade %v: exit code %d, expected %d\n%s", tt.args, code, tt.code, stderr)
+		}
+		if lines := strings.Count(stdout, "Grade Violation:"); lines != len(tt.violations) {
+			t.Errorf("assess grade %v: %d violations, expected %d:\n%s", tt.args, lines, len(tt.violations), stdout)
+		}
+		for _, violation := range tt.violations {
+			if !strings.Contains(stdout, violation) {
+				t.Errorf("assess grade %v does not report %q:\n%s", tt.args, violation, stdout)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"codeleft-cli/assessment"
+	"codeleft-cli/filter"
+	"codeleft-cli/read"
+	"codeleft-cli/write"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// baselineCommand implements "baseline".
+type baselineCommand struct{}
+
+func (c *baselineCommand) Name() string { return "baseline" }
+
+func (c *baselineCommand) Synopsis() string {
+	return "Snapshot the latest grades and the average coverage into .codeLeft/baseline.json for 'assess ratchet'."
+}
+
+func (c *baselineCommand) Run(args []string) int {
+	var opts Options
+	fs := newFlagSet("baseline", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if err := opts.validateWholeRepository(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if err := opts.validateThresholdGrade(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	coverage := assessment.NewAverages(gradeDetails, ws.Weigher).Overall
+	baseline := filter.NewBaseline(gradeDetails, opts.ThresholdGrade, opts.ToolList(), coverage, time.Now())
+	if strategy := ws.WeightingStrategy(); strategy != filter.WeightingStrategyUniform {
+		baseline.Weighting = strategy
+	}
+	if err := write.NewBaselineWriter(ws.BaselinePath()).WriteBaseline(baseline); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing baseline: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(os.Stderr, "Wrote a baseline of %d grade(s) and %.2f%% coverage to %s\n", len(baseline.Grades), baseline.Coverage, ws.BaselinePath())
+	return ExitOK
+}
+
+// WeightingStrategy returns the weighting strategy config.json selects.
+func (w *workspace) WeightingStrategy() string {
+	if w.Config.Weighting.Strategy == "" {
+		return filter.WeightingStrategyUniform
+	}
+	return w.Config.Weighting.Strategy
+}
+
+// BaselinePath returns the location of baseline.json inside the discovered .codeLeft
+// directory.
+func (w *workspace) BaselinePath() string {
+	return filepath.Join(filepath.Dir(w.HistoryReader.HistoryPath()), read.BaselineFileName)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+)
+
+const binaryName = "codeleft-cli"
+
+// Exit codes returned by the CLI. The grade gate has its own code so CI can tell
+// a letter-grade failure apart from a coverage failure.
+const (
+	ExitOK             = 0
+	ExitError          = 1
+	ExitCoverageFailed = 1
+	ExitGradeFailed    = 2
+)
+
+// Run executes the CLI with the given arguments (without the binary name) and returns the exit code.
+// Arguments that do not start with a subcommand are handled by the deprecated top-level flags.
+func Run(version string, args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runLegacy(version, args)
+	}
+	return newRootCommand(version).Run(args)
+}
+
+// newRootCommand assembles the full subcommand tree.
+func newRootCommand(version string) *CommandGroup {
+	return NewCommandGroup("", binaryName+" Version "+version,
+		NewCommandGroup("assess", "Fail the build when grades or coverage fall below a threshold.",
+			&assessGradeCommand{version: version},
+			&assessCoverageCommand{version: version},
+			&assessRatchetCommand{version: version},
+		),
+		NewCommandGroup("report", "Generate a coverage report from the latest grades.",
+			&reportCommand{format: "html"},
+			&reportCommand{format: "json"},
+			&reportMarkdownCommand{},
+			&reportBadgesCommand{},
+			&reportSarifCommand{version: version},
+			&reportCodeQualityCommand{},
+		),
+		NewCommandGroup("history", "Inspect and maintain .codeLeft/history.ndjson.",
+			&historyListCommand{},
+			&historyShowCommand{},
+			&historyPruneCommand{},
+		),
+		NewCommandGroup("config", "Inspect .codeLeft/config.json.",
+			&configValidateCommand{},
+			&configPrintCommand{},
+		),
+		&baselineCommand{},
+		&exportCommand{},
+		&initCommand{},
+	)
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Command is a single node in the codeleft-cli subcommand tree.
+type Command interface {
+	Name() string
+	Synopsis() string
+	Run(args []string) int
+}
+
+// CommandGroup dispatches to one of its child commands by name.
+// It owns no flags of its own; each leaf command defines its own flag set.
+type CommandGroup struct {
+	path     string
+	synopsis string
+	commands []Command
+	output   io.Writer
+}
+
+// NewCommandGroup creates a CommandGroup. The path is the full command path
+// below the binary name, e.g. "history" or "" for the root.
+func NewCommandGroup(path string, synopsis string, commands ...Command) *CommandGroup {
+	return &CommandGroup{
+		path:     path,
+		synopsis: synopsis,
+		commands: commands,
+		output:   os.Stderr,
+	}
+}
+
+func (g *CommandGroup) Name() string {
+	parts := strings.Fields(g.path)
+	if len(parts) == 0 {
+		return binaryName
+	}
+	return parts[len(parts)-1]
+}
+
+func (g *CommandGroup) Synopsis() string {
+	return g.synopsis
+}
+
+// Run selects the child named by the first argument and runs it with the rest.
+func (g *CommandGroup) Run(args []string) int {
+	if len(args) == 0 {
+		g.Usage()
+		return ExitError
+	}
+	if isHelpArg(args[0]) {
+		g.Usage()
+		return ExitOK
+	}
+
+	for _, command := range g.commands {
+		if command.Name() == args[0] {
+			return command.Run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(g.output, "Unknown command %q for %s\n\n", args[0], commandPath(g.path))
+	g.Usage()
+	return ExitError
+}
+
+// Usage prints the group's synopsis and the list of its child commands.
+func (g *CommandGroup) Usage() {
+	fmt.Fprintf(g.output, "%s\n\nUsage:\n  %s <command> [options]\n\nCommands:\n", g.synopsis, commandPath(g.path))
+	for _, command := range g.commands {
+		fmt.Fprintf(g.output, "  %-10s %s\n", command.Name(), command.Synopsis())
+	}
+	fmt.Fprintf(g.output, "\nRun '%s <command> -help' for the options of a command.\n", commandPath(g.path))
+}
+
+// newFlagSet creates the flag set for a leaf command, with usage text built from
+// the command path, a one-line synopsis and the positional arguments it accepts.
+func newFlagSet(path string, synopsis string, positional string) *flag.FlagSet {
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.Usage = func() {
+		usage := commandPath(path) + " [options]"
+		if positional != "" {
+			usage += " " + positional
+		}
+		fmt.Fprintf(fs.Output(), "%s\n\nUsage:\n  %s\n\nOptions:\n", synopsis, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args into fs. The returned exit code is only meaningful when ok is false.
+func parseFlags(fs *flag.FlagSet, args []string) (code int, ok bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK, false
+		}
+		return ExitError, false
+	}
+	return ExitOK, true
+}
+
+// commandPath prefixes a command path with the binary name.
+func commandPath(path string) string {
+	if path == "" {
+		return binaryName
+	}
+	return binaryName + " " + path
+}
+
+func isHelpArg(arg string) bool {
+	switch arg {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
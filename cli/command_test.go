@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordingCommand is a leaf command that records the arguments it was run with.
+type recordingCommand struct {
+	name string
+	args []string
+	runs int
+}
+
+func (c *recordingCommand) Name() string     { return c.name }
+func (c *recordingCommand) Synopsis() string { return "Synopsis of " + c.name }
+
+func (c *recordingCommand) Run(args []string) int {
+	c.args = args
+	c.runs++
+	return 7
+}
+
+func TestCommandGroupRun(t *testing.T) {
+	show := &recordingCommand{name: "show"}
+	list := &recordingCommand{name: "list"}
+	tests := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{[]string{"show", "-tools", "SOLID", "cli/a.go"}, 7, ""},
+		{[]string{"help"}, ExitOK, "Usage:\n  codeleft-cli history <command> [options]"},
+		{[]string{"-h"}, ExitOK, "  list       Synopsis of list\n"},
+		{nil, ExitError, "Commands:\n  show       Synopsis of show\n"},
+		{[]string{"Show"}, ExitError, `Unknown command "Show" for codeleft-cli history`},
+	}
+	for _, tt := range tests {
+		var output bytes.Buffer
+		group := NewCommandGroup("history", "Inspect history.", show, list)
+		group.output = &output
+
+		if code := group.Run(tt.args); code != tt.code {
+			t.Errorf("Run(%q) = %d, expected %d", tt.args, code, tt.code)
+		}
+		if !strings.Contains(output.String(), tt.output) {
+			t.Errorf("Run(%q) printed %q, expected it to contain %q", tt.args, output.String(), tt.output)
+		}
+	}
+
+	if show.runs != 1 || !reflect.DeepEqual(show.args, []string{"-tools", "SOLID", "cli/a.go"}) || list.runs != 0 {
+		t.Errorf("show ran %d times with %q and list %d times, expected show once with the remaining arguments", show.runs, show.args, list.runs)
+	}
+}
+
+func TestCommandGroupName(t *testing.T) {
+	for path, expected := range map[string]string{"": binaryName, "history": "history", "config print": "print"} {
+		if name := NewCommandGroup(path, "").Name(); name != expected {
+			t.Errorf("Name of %q = %s, expected %s", path, name, expected)
+		}
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		ok   bool
+	}{
+		{[]string{"-tools", "SOLID", "-force"}, ExitOK, true},
+		{[]string{"-help"}, ExitOK, false},
+		{[]string{"-threshold"}, ExitError, false},
+		{[]string{"-force=maybe"}, ExitError, false},
+	}
+	for _, tt := range tests {
+		fs := newFlagSet("history prune", "Prune history.", "")
+		fs.SetOutput(&bytes.Buffer{})
+		fs.String("tools", "", "")
+		fs.Bool("force", false, "")
+
+		if code, ok := parseFlags(fs, tt.args); code != tt.code || ok != tt.ok {
+			t.Errorf("parseFlags(%q) = %d, %v, expected %d, %v", tt.args, code, ok, tt.code, tt.ok)
+		}
+	}
+}
+
+func TestNewFlagSetUsage(t *testing.T) {
+	var output bytes.Buffer
+	fs := newFlagSet("history show", "Show the grades of a file.", "<file>")
+	fs.SetOutput(&output)
+	fs.Bool("all", false, "Show every record.")
+	if err := fs.Parse([]string{"-h"}); err != flag.ErrHelp {
+		t.Fatalf("Parse(-h) = %v, expected flag.ErrHelp", err)
+	}
+
+	expected := "Show the grades of a file.\n\nUsage:\n  codeleft-cli history show [options] <file>\n\nOptions:\n  -all\n"
+	if !strings.HasPrefix(output.String(), expected) {
+		t.Errorf("usage = %q, expected it to start with %q", output.String(), expected)
+	}
+}
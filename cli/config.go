@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"codeleft-cli/read"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// configValidateCommand implements "config validate".
+type configValidateCommand struct{}
+
+func (c *configValidateCommand) Name() string { return "validate" }
+
+func (c *configValidateCommand) Synopsis() string {
+	return "Check config.json against the config schema."
+}
+
+func (c *configValidateCommand) Run(args []string) int {
+	fs := newFlagSet("config validate", c.Synopsis(), "")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	configReader, err := read.NewConfigReader(read.NewOSFileSystem())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing config reader: %v\n", err)
+		return ExitError
+	}
+	data, err := configReader.ReadConfigBytes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+		return ExitError
+	}
+
+	problems, err := validateConfig(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	configPath, _ := configReader.ResolveConfigPath()
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stdout, formatProblem(configPath, problem))
+	}
+	if config, err := read.DecodeConfig(data); err == nil {
+		warnDeprecatedKeys(config)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "config.json has %d problem(s)\n", len(problems))
+		return ExitError
+	}
+
+	fmt.Fprintf(os.Stderr, "config.json is valid\n")
+	return ExitOK
+}
+
+// configPrintCommand implements "config print".
+type configPrintCommand struct{}
+
+func (c *configPrintCommand) Name() string { return "print" }
+
+func (c *configPrintCommand) Synopsis() string {
+	return "Print config.json as the CLI understands it."
+}
+
+func (c *configPrintCommand) Run(args []string) int {
+	fs := newFlagSet("config print", c.Synopsis(), "")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error printing config: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigValidateWarnsAboutCweAlias(t *testing.T) {
+	newTestProject(t, `{"threshold": "B", "security": {"owasp": true, "cwe": true}}`, nil)
+
+	var code int
+	_, stderr := captureOutput(t, func() { code = (&configValidateCommand{}).Run(nil) })
+	if code != ExitOK {
+		t.Errorf("exit code %d, expected %d: the deprecated key is still valid\n%s", code, ExitOK, stderr)
+	}
+	if !strings.Contains(stderr, "Warning: security.cwe is deprecated, rename it to security.cweTop25") {
+		t.Errorf("stderr %q does not warn about security.cwe", stderr)
+	}
+
+	newTestProject(t, `{"threshold": "B", "security": {"cweTop25": true}}`, nil)
+	_, stderr = captureOutput(t, func() { code = (&configValidateCommand{}).Run(nil) })
+	if code != ExitOK || strings.Contains(stderr, "deprecated") {
+		t.Errorf("exit code %d, stderr %q: expected no warning for security.cweTop25", code, stderr)
+	}
+}
+
+func TestConfigPrintKeepsUnknownAndDeprecatedKeys(t *testing.T) {
+	newTestProject(t, `{"extensionVersion": "1.4.0", "threshold": "B", "security": {"cwe": true}}`, nil)
+
+	var code int
+	stdout, _ := captureOutput(t, func() { code = (&configPrintCommand{}).Run(nil) })
+	if code != ExitOK {
+		t.Fatalf("exit code %d, expected %d", code, ExitOK)
+	}
+	expected := "{\n  \"extensionVersion\": \"1.4.0\",\n  \"threshold\": \"B\",\n  \"security\": {\n    \"cwe\": true\n  }\n}\n"
+	if stdout != expected {
+		t.Errorf("config print wrote\n%s\nexpected\n%s", stdout, expected)
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"codeleft-cli/report"
+	"fmt"
+	"io"
+	"os"
+)
+
+// exportCommand implements "export".
+type exportCommand struct{}
+
+func (c *exportCommand) Name() string { return "export" }
+
+func (c *exportCommand) Synopsis() string {
+	return "Export the latest grades, or the full history, as CSV or TSV."
+}
+
+func (c *exportCommand) Run(args []string) int {
+	var opts Options
+	var format, columnList, outputPath string
+	var fullHistory bool
+	fs := newFlagSet("export", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&format, "format", "csv", "Output format: csv or tsv.")
+	fs.StringVar(&columnList, "columns", "", "Comma-separated columns: path, tool, grade, score, coverage, username, timestamp, hash, gradingDetails or gradingDetails.<sub-score>. (default: all but gradingDetails)")
+	fs.BoolVar(&fullHistory, "history", false, "Export every record in history.ndjson instead of the latest grade per file and tool.")
+	fs.StringVar(&outputPath, "output", "-", "Path of the export, or - for stdout.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	columns, err := report.ParseExportColumns(columnList)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	var exporter *report.TabularExporter
+	switch format {
+	case "csv":
+		exporter = report.NewCSVExporter(columns)
+	case "tsv":
+		exporter = report.NewTSVExporter(columns)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown -format %q: expected csv or tsv\n", format)
+		return ExitError
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	// Grades that are not on their tool's scale are exported without score or coverage.
+	var rows []report.ExportRow
+	if fullHistory {
+		rows = ws.historyExportRows(opts.Threshold())
+	} else {
+		latest := ws.latestHistories(opts.ToolList())
+		ws.warnUnknownGrades(latest)
+		rows = report.NewLatestExportRows(ws.collectGradeDetails(latest, opts.ToolList(), opts.Threshold()), latest, ws.Scales)
+	}
+
+	var output io.Writer = os.Stdout
+	if outputPath != "-" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating export: %v\n", err)
+			return ExitError
+		}
+		defer file.Close()
+		output = file
+	}
+	if err := exporter.Export(output, rows); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if outputPath != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d row(s) to %s\n", len(rows), outputPath)
+	}
+	return ExitOK
+}
+
+// historyExportRows builds a row for every record in history.ndjson as it was read, with
+// the path it was recorded under. Unlike the latest grades, no record is left out: not
+// those whose path cannot be mapped into the repository, nor those of ignored files.
+func (w *workspace) historyExportRows(defaults filter.Threshold) []report.ExportRow {
+	w.warnUnknownGrades(w.Records)
+	thresholds := recordedPathThresholds{thresholds: w.Thresholds(defaults), canonical: w.canonicalPaths()}
+	return report.NewHistoryExportRows(w.Records, w.Scales, w.Coverage, thresholds)
+}
+
+// recordedPathThresholds resolves thresholds for paths as recorded, through the canonical
+// path they map to, so that the path rules in config.json apply to them as well.
+type recordedPathThresholds struct {
+	thresholds filter.ThresholdResolver
+	canonical  map[string]string // Canonical path by recorded path
+}
+
+func (r recordedPathThresholds) Resolve(tool string, filePath string) filter.Threshold {
+	if canonicalPath, ok := r.canonical[filePath]; ok {
+		filePath = canonicalPath
+	}
+	return r.thresholds.Resolve(tool, filePath)
+}
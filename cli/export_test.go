@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"testing"
+)
+
+func TestWorkspaceHistoryExportRows(t *testing.T) {
+	pattern := filter.ParseConfigIgnorePatterns([]string{"cli/"})[0]
+
+	records := filter.Histories{
+		{Line: 1, FilePath: "/home/ci/repo/cli/a.go", AssessingTool: "SOLID", Grade: "C"},
+		{Line: 2, FilePath: "/home/someone/elsewhere/x.go", AssessingTool: "SOLID", Grade: "C"},
+		{Line: 3, FilePath: "/home/ci/repo/gen/b.go", AssessingTool: "SOLID", Grade: "Z"},
+	}
+	ws := &workspace{
+		HistoryReader: &fakeHistoryReader{},
+		Records:       records,
+		// The unmapped record on line 2 is not among the canonical histories, and line 3
+		// is dropped later by the ignore rules.
+		Histories:      filter.Histories{{Line: 1, FilePath: "cli/a.go", AssessingTool: "SOLID", Grade: "C"}, {Line: 3, FilePath: "gen/b.go", AssessingTool: "SOLID", Grade: "Z"}},
+		Scales:         filter.NewGradeScales(nil),
+		Coverage:       filter.NewDefaultCoverageCalculator(),
+		ThresholdRules: []filter.ThresholdRule{{Path: &pattern, Grade: "C"}},
+	}
+
+	rows := ws.historyExportRows(filter.Threshold{Grade: "B"})
+
+	expected := []struct {
+		path     string
+		score    string
+		coverage string
+	}{
+		{"/home/ci/repo/cli/a.go", "5", "100"}, // Held to C by the cli/ rule through its canonical path
+		{"/home/someone/elsewhere/x.go", "5", "70"},
+		{"/home/ci/repo/gen/b.go", "", ""},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("rows = %+v, expected one per record", rows)
+	}
+	for i, row := range rows {
+		if row.Path != expected[i].path || row.Score != expected[i].score || row.Coverage != expected[i].coverage {
+			t.Errorf("row %d = %s score %q coverage %q, expected %+v", i, row.Path, row.Score, row.Coverage, expected[i])
+		}
+	}
+}
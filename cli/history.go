@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"codeleft-cli/read"
+	"codeleft-cli/write"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// historyListCommand implements "history list".
+type historyListCommand struct{}
+
+func (c *historyListCommand) Name() string { return "list" }
+
+func (c *historyListCommand) Synopsis() string {
+	return "List the latest grade per file and tool."
+}
+
+func (c *historyListCommand) Run(args []string) int {
+	var tools string
+	var all, skipMissing bool
+	fs := newFlagSet("history list", c.Synopsis(), "")
+	fs.StringVar(&tools, "tools", "", "Comma-separated list of tools to include (default: all tools).")
+	fs.BoolVar(&all, "all", false, "List every record instead of only the latest per file and tool.")
+	fs.BoolVar(&skipMissing, "skip-missing", false, "Exclude records for files that no longer exist in the working tree.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	ws.SkipMissing = skipMissing
+
+	histories := ws.Histories
+	if !all {
+		histories = filter.NewLatestGrades().FilterLatestGrades(histories)
+	}
+	if toolList := parseTools(tools); len(toolList) > 0 {
+		histories = filter.NewToolFilter(filter.NewToolCleaner()).Filter(toolList, histories)
+	}
+	histories = ws.applyIgnoreRules(histories)
+	histories = ws.applyExistenceFilter(histories)
+	ws.warnUnknownGrades(histories)
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		if histories[i].FilePath != histories[j].FilePath {
+			return histories[i].FilePath < histories[j].FilePath
+		}
+		if histories[i].AssessingTool != histories[j].AssessingTool {
+			return histories[i].AssessingTool < histories[j].AssessingTool
+		}
+		return histories[i].TimeStamp.Before(histories[j].TimeStamp)
+	})
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tTOOL\tGRADE\tTIMESTAMP\tUSERNAME")
+	for _, history := range histories {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", history.FilePath, history.AssessingTool, history.Grade, history.TimeStamp.Format(time.RFC3339), history.Username)
+	}
+	tw.Flush()
+	return ExitOK
+}
+
+// historyShowCommand implements "history show".
+type historyShowCommand struct{}
+
+func (c *historyShowCommand) Name() string { return "show" }
+
+func (c *historyShowCommand) Synopsis() string {
+	return "Show every recorded grade for one file, oldest first."
+}
+
+func (c *historyShowCommand) Run(args []string) int {
+	var tools string
+	fs := newFlagSet("history show", c.Synopsis(), "<path>")
+	fs.StringVar(&tools, "tools", "", "Comma-separated list of tools to include (default: all tools).")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitError
+	}
+	target := filepath.ToSlash(fs.Arg(0))
+	toolList := parseTools(tools)
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	matches := filter.Histories{}
+	for _, history := range ws.Histories {
+		if matchesHistoryPath(history.FilePath, target) && matchesTool(history.AssessingTool, toolList) {
+			matches = append(matches, history)
+		}
+	}
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No history found for %s\n", target)
+		return ExitError
+	}
+	sort.Stable(matches)
+	ws.warnUnknownGrades(matches)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIMESTAMP\tTOOL\tGRADE\tUSERNAME\tHASH\tREVIEW")
+	for _, history := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", history.TimeStamp.Format(time.RFC3339), history.AssessingTool, history.Grade, history.Username, shortHash(history.Hash), history.ReviewSummary())
+	}
+	tw.Flush()
+	return ExitOK
+}
+
+// historyPruneCommand implements "history prune".
+type historyPruneCommand struct{}
+
+func (c *historyPruneCommand) Name() string { return "prune" }
+
+func (c *historyPruneCommand) Synopsis() string {
+	return "Rewrite history.ndjson without records that no longer matter."
+}
+
+func (c *historyPruneCommand) Run(args []string) int {
+	var keepLatest, missing, dryRun bool
+	fs := newFlagSet("history prune", c.Synopsis(), "")
+	fs.BoolVar(&keepLatest, "keep-latest", false, "Drop every record superseded by a newer one for the same file and tool.")
+	fs.BoolVar(&missing, "missing", false, "Drop every record for a file that no longer exists in the working tree.")
+	fs.BoolVar(&dryRun, "dry-run", false, "Report what would be pruned without rewriting history.ndjson.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !keepLatest && !missing {
+		fmt.Fprintf(os.Stderr, "Nothing to prune: pass -keep-latest and/or -missing\n")
+		return ExitError
+	}
+
+	historyReader, err := read.NewHistoryReader()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing history reader: %v\n", err)
+		return ExitError
+	}
+	records, err := historyReader.ReadRecords()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		return ExitError
+	}
+
+	repoRoot, canonicaliser, err := newPathCanonicaliser()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	kept := records
+	if keepLatest {
+		kept = supersededRecordsPruned(kept, canonicaliser)
+	}
+	if missing {
+		kept = missingRecordsPruned(kept, canonicaliser, read.NewOSFileChecker(repoRoot))
+	}
+	pruned := len(records) - len(kept)
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "Would prune %d of %d records from %s\n", pruned, len(records), historyReader.HistoryPath())
+		return ExitOK
+	}
+	if pruned == 0 {
+		fmt.Fprintf(os.Stderr, "Nothing to prune in %s\n", historyReader.HistoryPath())
+		return ExitOK
+	}
+
+	if err := write.NewHistoryWriter(historyReader.HistoryPath()).WriteRecords(kept); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing history: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(os.Stderr, "Pruned %d of %d records from %s\n", pruned, len(records), historyReader.HistoryPath())
+	return ExitOK
+}
+
+// supersededRecordsPruned keeps only the newest record per file and tool, in file order.
+// Records are grouped by canonical path, so the same file recorded under different
+// prefixes counts once; records that cannot be mapped are grouped by their raw path.
+func supersededRecordsPruned(records []read.HistoryRecord, canonicaliser *filter.PathCanonicaliser) []read.HistoryRecord {
+	recordKey := func(record read.HistoryRecord) string {
+		path, ok := canonicaliser.CanonicalPath(record.History.FilePath)
+		if !ok {
+			path = record.History.FilePath
+		}
+		return path + "|" + record.History.AssessingTool
+	}
+
+	latest := make(map[string]read.HistoryRecord)
+	for _, record := range records {
+		key := recordKey(record)
+		if stored, exists := latest[key]; !exists || !record.History.TimeStamp.Before(stored.History.TimeStamp) {
+			latest[key] = record
+		}
+	}
+
+	kept := []read.HistoryRecord{}
+	for _, record := range records {
+		key := recordKey(record)
+		if latest[key].Line == record.Line {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
+
+// missingRecordsPruned drops the records whose file no longer exists in the working tree.
+// Records whose path cannot be mapped into the repository are kept, since there is no
+// file to check them against.
+func missingRecordsPruned(records []read.HistoryRecord, canonicaliser *filter.PathCanonicaliser, checker filter.FileExistenceChecker) []read.HistoryRecord {
+	kept := []read.HistoryRecord{}
+	for _, record := range records {
+		path, ok := canonicaliser.CanonicalPath(record.History.FilePath)
+		if !ok || checker.Exists(path) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
+
+// matchesHistoryPath reports whether a recorded path refers to the requested path.
+// A suffix match on a path boundary is accepted so that "show" also works from a
+// subdirectory of the repository.
+func matchesHistoryPath(recorded string, target string) bool {
+	recorded = filepath.ToSlash(recorded)
+	return recorded == target || strings.HasSuffix(recorded, "/"+strings.TrimPrefix(target, "./"))
+}
+
+// matchesTool reports whether tool is one of tools; an empty list matches everything.
+func matchesTool(tool string, tools []string) bool {
+	if len(tools) == 0 {
+		return true
+	}
+	for _, candidate := range tools {
+		if strings.EqualFold(strings.TrimSpace(candidate), tool) {
+			return true
+		}
+	}
+	return false
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistoryListSkipMissing(t *testing.T) {
+	newTestProject(t, `{"threshold": "B"}`, []string{
+		historyLine("cli/a.go", "SOLID", "A", 1),
+		historyLine("cli/gone.go", "SOLID", "C", 1),
+	}, "cli/a.go")
+
+	tests := []struct {
+		args   []string
+		listed []string
+		absent []string
+	}{
+		{nil, []string{"cli/a.go", "cli/gone.go"}, nil},
+		{[]string{"-skip-missing"}, []string{"cli/a.go"}, []string{"cli/gone.go"}},
+	}
+	for _, tt := range tests {
+		var code int
+		stdout, stderr := captureOutput(t, func() { code = (&historyListCommand{}).Run(tt.args) })
+		if code != ExitOK {
+			t.Fatalf("history list %v: exit code %d\n%s", tt.args, code, stderr)
+		}
+		for _, path := range tt.listed {
+			if !strings.Contains(stdout, path) {
+				t.Errorf("history list %v does not list %s:\n%s", tt.args, path, stdout)
+			}
+		}
+		for _, path := range tt.absent {
+			if strings.Contains(stdout, path) {
+				t.Errorf("history list %v lists the missing %s:\n%s", tt.args, path, stdout)
+			}
+		}
+	}
+}
+
+func TestHistoryPruneMissing(t *testing.T) {
+	kept := historyLine("cli/a.go", "SOLID", "A", 1)
+	unmapped := historyLine("/somewhere/else/x.go", "SOLID", "B", 1)
+	root := newTestProject(t, `{"threshold": "B"}`, []string{
+		kept,
+		historyLine("cli/gone.go", "SOLID", "C", 1),
+		unmapped,
+	}, "cli/a.go")
+
+	var code int
+	_, stderr := captureOutput(t, func() { code = (&historyPruneCommand{}).Run([]string{"-missing"}) })
+	if code != ExitOK || !strings.Contains(stderr, "Pruned 1 of 3 records") {
+		t.Fatalf("exit code %d, stderr %q, expected one record pruned", code, stderr)
+	}
+	// Records outside the repository have no file to check, so they are kept.
+	assertHistoryFile(t, root, kept, unmapped)
+}
+
+// assertHistoryFile fails the test unless history.ndjson holds exactly the lines.
+func assertHistoryFile(t *testing.T, root string, lines ...string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, ".codeLeft", "history.ndjson"))
+	if err != nil {
+		t.Fatalf("reading history.ndjson: %v", err)
+	}
+	if expected := strings.Join(lines, "\n") + "\n"; string(data) != expected {
+		t.Errorf("history.ndjson =\n%s\nexpected\n%s", data, expected)
+	}
+}
+
+func TestHistoryPruneKeepLatest(t *testing.T) {
+	newest := historyLine("cli/a.go", "SOLID", "A", 3)
+	other := historyLine("cli/a.go", "OWASP-TOP-10", "B", 1)
+	history := []string{
+		historyLine("cli/a.go", "SOLID", "C", 1),
+		other,
+		newest,
+		historyLine("cli/gone.go", "SOLID", "B", 1),
+	}
+	root := newTestProject(t, `{"threshold": "B"}`, history, "cli/a.go")
+	// The same file recorded under an absolute path is superseded by the newer relative record.
+	absolute := historyLine(filepath.ToSlash(filepath.Join(root, "cli", "a.go")), "SOLID", "B", 2)
+	history = append(history[:1], append([]string{absolute}, history[1:]...)...)
+	historyPath := filepath.Join(root, ".codeLeft", "history.ndjson")
+	if err := os.WriteFile(historyPath, []byte(strings.Join(history, "\n")), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(historyPath, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+		kept   []string
+	}{
+		{nil, ExitError, "Nothing to prune: pass -keep-latest and/or -missing", history},
+		{[]string{"-keep-latest", "-dry-run"}, ExitOK, "Would prune 2 of 5 records", history},
+		{[]string{"-keep-latest", "-missing", "-dry-run"}, ExitOK, "Would prune 3 of 5 records", history},
+		{[]string{"-keep-latest"}, ExitOK, "Pruned 2 of 5 records", []string{other, newest, history[4]}},
+		{[]string{"-keep-latest"}, ExitOK, "Nothing to prune in", []string{other, newest, history[4]}},
+	}
+	for _, tt := range tests {
+		var code int
+		_, stderr := captureOutput(t, func() { code = (&historyPruneCommand{}).Run(tt.args) })
+		if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+			t.Errorf("history prune %v: exit code %d and %q, expected %d and %q", tt.args, code, stderr, tt.code, tt.stderr)
+		}
+		if len(tt.kept) == len(history) {
+			assertHistoryContent(t, root, strings.Join(history, "\n"))
+		} else {
+			assertHistoryFile(t, root, tt.kept...)
+		}
+	}
+
+	info, err := os.Stat(historyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("history.ndjson mode %v, expected the original 0600 to be kept", mode)
+	}
+}
+
+// assertHistoryContent fails the test unless history.ndjson is exactly content.
+func assertHistoryContent(t *testing.T, root string, content string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, ".codeLeft", "history.ndjson"))
+	if err != nil {
+		t.Fatalf("reading history.ndjson: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("history.ndjson was rewritten:\n%s", data)
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"codeleft-cli/read"
+	"codeleft-cli/types"
+	"codeleft-cli/write"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// initCommand implements "init".
+type initCommand struct{}
+
+func (c *initCommand) Name() string { return "init" }
+
+func (c *initCommand) Synopsis() string {
+	return "Create .codeLeft with a commented default config.json and an empty history.ndjson."
+}
+
+func (c *initCommand) Run(args []string) int {
+	var force bool
+	var languages string
+	fs := newFlagSet("init", c.Synopsis(), "")
+	fs.BoolVar(&force, "force", false, "Overwrite an existing config.json and history.ndjson.")
+	fs.StringVar(&languages, "languages", "", "Comma-separated languages to choose defaults for (default: detected from the project's files).")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get current working directory: %v\n", err)
+		return ExitError
+	}
+
+	languageList, err := c.languages(fs, languages, root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error detecting project languages: %v\n", err)
+		return ExitError
+	}
+
+	config := types.NewDefaultConfigForLanguages(languageList)
+	written, err := write.NewScaffolder(root, force).Scaffold(config, languageList)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initialising .codeLeft: %v\n", err)
+		return ExitError
+	}
+	for _, path := range written {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	}
+	return ExitOK
+}
+
+// languages returns the languages given with -languages, or detects them from the project.
+func (c *initCommand) languages(fs *flag.FlagSet, languages string, root string) ([]string, error) {
+	explicit := false
+	fs.Visit(func(f *flag.Flag) {
+		explicit = explicit || f.Name == "languages"
+	})
+	if explicit {
+		return parseTools(languages), nil
+	}
+
+	detected, err := read.NewExtensionLanguageDetector().DetectLanguages(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(detected) > 0 {
+		fmt.Fprintf(os.Stderr, "Detected languages: %s\n", strings.Join(detected, ", "))
+	}
+	return detected, nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"codeleft-cli/report"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// runLegacy handles the original top-level flags. They remain as deprecated aliases
+// of the subcommands so existing CI workflows keep working unchanged.
+func runLegacy(version string, args []string) int {
+	var opts Options
+	fs := flag.NewFlagSet(binaryName, flag.ContinueOnError)
+	opts.bindGradeFlags(fs)
+	opts.bindCoverageFlags(fs)
+	versionFlag := fs.Bool("version", false, "Displays the current version of the CLI tool.")
+	assessGrade := fs.Bool("asses-grade", false, "Deprecated: use 'assess grade'. Assess the grade threshold.")
+	assessCoverage := fs.Bool("asses-coverage", false, "Deprecated: use 'assess coverage'. Assess the coverage threshold.")
+	createReport := fs.Bool("create-report", false, "Deprecated: use 'report html'. Create a report of the assessment.")
+
+	// Customize the usage message to include version information and the subcommands
+	fs.Usage = func() {
+		newRootCommand(version).Usage()
+		fmt.Fprintf(fs.Output(), "\nDeprecated top-level options:\n")
+		fs.PrintDefaults()
+	}
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	// Handle version flag
+	if *versionFlag {
+		fmt.Fprintf(os.Stderr, "codeleft-cli Version %s\n", version)
+		return ExitOK
+	}
+
+	warnDeprecatedFlags(fs)
+	if !flagWasSet(fs, "stale-policy") {
+		opts.StalePolicy = string(filter.StalePolicyOff) // The deprecated flags predate stale detection
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	// Unlike the subcommands, stop at the first failing gate, as the original binary did.
+	gates := newGateRunner(true, ws)
+	if *assessGrade {
+		gates.gradeGate(opts, gradeDetails)
+		if gates.exitCode != ExitOK {
+			return gates.exitCode
+		}
+	}
+	if *assessCoverage {
+		gates.coverageGate(opts, gradeDetails)
+		if gates.exitCode != ExitOK {
+			return gates.exitCode
+		}
+	}
+	if *assessGrade || *assessCoverage {
+		gates.workspaceGates(opts, ws, gradeDetails)
+		if gates.exitCode != ExitOK {
+			return gates.exitCode
+		}
+	}
+
+	if *createReport {
+		if code := writeReport(report.NewHtmlReport(report.DefaultHTMLReportPath), opts, ws); code != ExitOK {
+			return code
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "All checks passed!\n")
+	return ExitOK
+}
+
+// legacyReplacements maps each deprecated flag to the subcommand that replaces it.
+var legacyReplacements = map[string]string{
+	"asses-grade":    "assess grade",
+	"asses-coverage": "assess coverage",
+	"create-report":  "report html",
+}
+
+// flagWasSet reports whether the flag was given on the command line.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// warnDeprecatedFlags prints a notice for every deprecated flag that was set.
+func warnDeprecatedFlags(fs *flag.FlagSet) {
+	fs.Visit(func(f *flag.Flag) {
+		if replacement, ok := legacyReplacements[f.Name]; ok {
+			fmt.Fprintf(os.Stderr, "Warning: -%s is deprecated, use '%s %s' instead\n", f.Name, binaryName, replacement)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunLegacyStopsAtFirstFailingGate(t *testing.T) {
+	// Against B, the A counts as 120% and the C as 70%: 95% on average.
+	history := []string{
+		historyLine("cli/a.go", "SOLID", "A", 1),
+		historyLine("cli/b.go", "SOLID", "C", 1),
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		failed   string
+		reported string // The prefix of every violation line, from the only gate that ran
+	}{
+		{
+			name:     "both gates fail",
+			args:     []string{"-asses-grade", "-asses-coverage", "-threshold-grade", "B", "-threshold-percent", "100"},
+			code:     ExitGradeFailed,
+			failed:   "Grade threshold failed",
+			reported: "Grade Violation: ",
+		},
+		{
+			name:     "coverage fails",
+			args:     []string{"-asses-grade", "-asses-coverage", "-threshold-grade", "C", "-threshold-percent", "200"},
+			code:     ExitCoverageFailed,
+			failed:   "Coverage threshold failed",
+			reported: "Violation: ",
+		},
+		{
+			name: "both gates pass",
+			args: []string{"-asses-grade", "-asses-coverage", "-threshold-grade", "C", "-threshold-percent", "90"},
+			code: ExitOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestProject(t, `{"threshold": "B"}`, history, "cli/a.go", "cli/b.go")
+
+			var code int
+			stdout, stderr := captureOutput(t, func() { code = Run("1.2.3", append([]string{"-tools", "SOLID"}, tt.args...)) })
+			if code != tt.code {
+				t.Errorf("exit code %d, expected %d\n%s", code, tt.code, stderr)
+			}
+			for _, flag := range []string{"asses-grade", "asses-coverage"} {
+				if !strings.Contains(stderr, "Warning: -"+flag+" is deprecated") {
+					t.Errorf("no deprecation warning for -%s:\n%s", flag, stderr)
+				}
+			}
+			if tt.code == ExitOK {
+				if !strings.Contains(stderr, "All checks passed!") || stdout != "" {
+					t.Errorf("passing run printed %q and %q", stdout, stderr)
+				}
+				return
+			}
+			if strings.Count(stderr, "threshold failed") != 1 || !strings.Contains(stderr, tt.failed) {
+				t.Errorf("stderr does not report only %q:\n%s", tt.failed, stderr)
+			}
+			for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+				if !strings.HasPrefix(line, tt.reported) {
+					t.Errorf("stdout line %q, expected only lines starting with %q", line, tt.reported)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLegacyStalePolicy(t *testing.T) {
+	stale := hashedHistoryLine("cli/a.go", "SOLID", "A", 1, strings.Repeat("0", 64))
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"-asses-grade"}, ExitOK}, // The deprecated flags predate stale detection
+		{[]string{"-asses-grade", "-stale-policy", "fail"}, ExitGradeFailed},
+	}
+	for _, tt := range tests {
+		newTestProject(t, `{"threshold": "B"}`, []string{stale}, "cli/a.go")
+
+		var code int
+		_, stderr := captureOutput(t, func() { code = Run("1.2.3", append([]string{"-tools", "SOLID"}, tt.args...)) })
+		if code != tt.code {
+			t.Errorf("%v: exit code %d, expected %d\n%s", tt.args, code, tt.code, stderr)
+		}
+		if (tt.code == ExitOK) == strings.Contains(stderr, "stale") {
+			t.Errorf("%v: unexpected stale grade report:\n%s", tt.args, stderr)
+		}
+	}
+}
+
+func TestRunLegacyFlags(t *testing.T) {
+	root := newTestProject(t, `{"threshold": "B"}`, []string{historyLine("cli/a.go", "SOLID", "B", 1)}, "cli/a.go")
+	tests := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{"-version"}, ExitOK, "codeleft-cli Version 1.2.3\n"},
+		{[]string{"-help"}, ExitOK, "Deprecated top-level options:"},
+		{[]string{"-asses-grades"}, ExitError, "flag provided but not defined: -asses-grades"},
+		{[]string{"-tools", "SOLID", "-create-report"}, ExitOK, "Warning: -create-report is deprecated, use 'codeleft-cli report html' instead"},
+	}
+	for _, tt := range tests {
+		var code int
+		_, stderr := captureOutput(t, func() { code = Run("1.2.3", tt.args) })
+		if code != tt.code || !strings.Contains(stderr, tt.stderr) {
+			t.Errorf("%v: exit code %d and stderr %q, expected %d and %q", tt.args, code, stderr, tt.code, tt.stderr)
+		}
+	}
+	if _, err := os.Stat(root + "/CodeLeft-Coverage-Report.html"); err != nil {
+		t.Errorf("-create-report did not write the HTML report: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"codeleft-cli/types"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Options holds the flags shared by the commands that work on graded history.
+type Options struct {
+	ThresholdGrade   string
+	ThresholdPercent int
+	Tools            string
+	ExplainIgnores   bool
+	SkipMissing      bool
+	StalePolicy      string
+	ChangedSince     string
+	Unassessed       string
+}
+
+// bindGradeFlags registers the flags needed to turn history into GradeDetails.
+func (o *Options) bindGradeFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.ThresholdGrade, "threshold-grade", "", "Sets the grade threshold. (default: threshold in config.json)")
+	fs.StringVar(&o.Tools, "tools", "", "Comma-separated list of tooling (e.g., SOLID,OWASP-Top-10,Clean-Code,...) (default: tools enabled in config.json)")
+	fs.BoolVar(&o.ExplainIgnores, "explain-ignores", false, "Print every excluded file and the ignore rule that excluded it.")
+	fs.BoolVar(&o.SkipMissing, "skip-missing", false, "Exclude records for files that no longer exist in the working tree.")
+	fs.StringVar(&o.ChangedSince, "changed-since", "", "Only consider files changed since the merge base of HEAD and this git ref, and require a fresh grade for each of them.")
+	fs.StringVar(&o.Unassessed, "unassessed", "", "How source files without a grade count: off, zero (0% coverage) or fail. (default: sources.unassessed in config.json, else off)")
+	fs.StringVar(&o.StalePolicy, "stale-policy", string(filter.StalePolicyWarn), "What to do with grades recorded for an older version of a file: warn, fail, exclude or off.")
+}
+
+// bindCoverageFlags registers the coverage percentage threshold.
+func (o *Options) bindCoverageFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.ThresholdPercent, "threshold-percent", 0, "Sets the percentage threshold.")
+}
+
+// applyConfigDefaults fills in the tools, unassessed policy and threshold grade from
+// config.json when the corresponding flags were not given. Explicit flags always win.
+func (o *Options) applyConfigDefaults(config *types.Config) {
+	if o.Tools == "" {
+		if tools := config.EnabledTools(); len(tools) > 0 {
+			o.Tools = strings.Join(tools, ",")
+			fmt.Fprintf(os.Stderr, "Using tools enabled in config.json: %s\n", strings.Join(tools, ", "))
+		}
+	}
+	if o.Unassessed == "" {
+		o.Unassessed = string(filter.UnassessedPolicyOff)
+		if config.Sources.Unassessed != "" {
+			o.Unassessed = config.Sources.Unassessed
+			fmt.Fprintf(os.Stderr, "Using unassessed policy from config.json: %s\n", config.Sources.Unassessed)
+		}
+	}
+	if o.ThresholdGrade == "" && config.Threshold != "" {
+		o.ThresholdGrade = config.Threshold
+		fmt.Fprintf(os.Stderr, "Using threshold grade from config.json: %s\n", config.Threshold)
+	}
+}
+
+// ToolList returns the tools flag as a slice.
+func (o *Options) ToolList() []string {
+	return parseTools(o.Tools)
+}
+
+// Threshold returns the threshold flags, the fallback for files and tools that no rule in
+// the thresholds section of config.json covers.
+func (o *Options) Threshold() filter.Threshold {
+	return filter.Threshold{Grade: o.ThresholdGrade, Percent: o.ThresholdPercent}
+}
+
+// validateThresholdGrade ensures the threshold grade exists on the grade scale.
+func (o *Options) validateThresholdGrade() error {
+	if !filter.IsKnownGrade(o.ThresholdGrade) {
+		return fmt.Errorf("a valid -threshold-grade is required, got %q", o.ThresholdGrade)
+	}
+	return nil
+}
+
+// validateResolvedThresholdGrades ensures every grade is held to a threshold grade on the
+// grade scale. Only the thresholds the rules in config.json resolve to are checked, so
+// -threshold-grade may be left out when the rules cover every file and tool.
+func (o *Options) validateResolvedThresholdGrades(details []filter.GradeDetails) error {
+	for _, detail := range details {
+		if detail.ThresholdGrade == "" {
+			return fmt.Errorf("a valid -threshold-grade is required: no rule in the thresholds of config.json covers %s for %s", detail.FileName, detail.Tool)
+		}
+		if !filter.IsKnownGrade(detail.ThresholdGrade) {
+			return fmt.Errorf("a valid -threshold-grade is required, got %q", detail.ThresholdGrade)
+		}
+	}
+	return nil
+}
+
+// validateWholeRepository rejects the flags that leave files out of the grades. The
+// baseline's coverage is that of the whole repository, so a subset cannot be compared with
+// it, nor raise it. Must run before the config defaults are applied.
+func (o *Options) validateWholeRepository() error {
+	if o.ChangedSince != "" {
+		return fmt.Errorf("-changed-since cannot be used with the baseline, whose coverage covers the whole repository")
+	}
+	if filter.StalePolicy(o.StalePolicy) == filter.StalePolicyExclude {
+		return fmt.Errorf("-stale-policy %s cannot be used with the baseline, whose coverage covers the whole repository", filter.StalePolicyExclude)
+	}
+	if o.Unassessed != "" {
+		return fmt.Errorf("-unassessed cannot be used with the baseline: set sources.unassessed in config.json instead, so that the baseline and the ratchet count unassessed files alike")
+	}
+	return nil
+}
+
+// parseTools splits the comma-separated tools flag into a slice of strings.
+func parseTools(toolsFlag string) []string {
+	if toolsFlag == "" {
+		return []string{}
+	}
+	// Split on comma and trim spaces
+	tools := strings.Split(toolsFlag, ",")
+	for i := range tools {
+		tools[i] = strings.TrimSpace(tools[i])
+	}
+
+	return tools
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"strings"
+	"testing"
+)
+
+func TestValidateResolvedThresholdGrades(t *testing.T) {
+	pattern := filter.ParseConfigIgnorePatterns([]string{"cli/"})[0]
+	coveringRules := []filter.ThresholdRule{{Tool: "SOLID", Grade: "B"}, {Tool: "OWASP-TOP-10", Grade: "A"}, {Path: &pattern, Grade: "C"}}
+
+	tests := []struct {
+		name    string
+		global  string
+		rules   []filter.ThresholdRule
+		problem string // Substring of the expected error, empty for none
+	}{
+		{"global grade", "B", nil, ""},
+		{"rules cover every pair without a global grade", "", coveringRules, ""},
+		{"rules cover every pair despite an invalid global grade", "Q", coveringRules, ""},
+		{"missing global grade", "", nil, "no rule in the thresholds of config.json covers cli/a.go for SOLID"},
+		{"invalid global grade", "Q", nil, `got "Q"`},
+		{"uncovered pair", "", coveringRules[:1], "covers cli/a.go for OWASP-TOP-10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{ThresholdGrade: tt.global}
+			thresholds := filter.NewThresholds(opts.Threshold(), tt.rules)
+			details := []filter.GradeDetails{
+				{FileName: "cli/a.go", Tool: "SOLID"},
+				{FileName: "cli/a.go", Tool: "OWASP-TOP-10"},
+				{FileName: "filter/b.go", Tool: "SOLID"},
+				{FileName: "filter/b.go", Tool: "OWASP-TOP-10"},
+			}
+			for i := range details {
+				details[i].ApplyThreshold(thresholds.Resolve(details[i].Tool, details[i].FileName))
+			}
+
+			err := opts.validateResolvedThresholdGrades(details)
+			if tt.problem == "" {
+				if err != nil {
+					t.Errorf("validateResolvedThresholdGrades: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.problem) {
+				t.Errorf("error %v, expected it to contain %q", err, tt.problem)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"codeleft-cli/report"
+	"fmt"
+	"os"
+)
+
+// reportCommand implements "report html" and "report json".
+type reportCommand struct {
+	format string
+}
+
+func (c *reportCommand) Name() string { return c.format }
+
+func (c *reportCommand) Synopsis() string {
+	switch c.format {
+	case "json":
+		return "Write the coverage report tree as JSON."
+	default:
+		return "Write the coverage report as a standalone HTML page."
+	}
+}
+
+func (c *reportCommand) Run(args []string) int {
+	var opts Options
+	var outputPath string
+	fs := newFlagSet("report "+c.format, c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&outputPath, "output", c.defaultOutputPath(), "Path of the generated report.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	return writeReport(c.newReport(outputPath), opts, ws)
+}
+
+func (c *reportCommand) defaultOutputPath() string {
+	if c.format == "json" {
+		return report.DefaultJSONReportPath
+	}
+	return report.DefaultHTMLReportPath
+}
+
+func (c *reportCommand) newReport(outputPath string) report.IReport {
+	if c.format == "json" {
+		return report.NewJsonReport(outputPath)
+	}
+	return report.NewHtmlReport(outputPath)
+}
+
+// writeReport generates a report from the workspace's latest grades.
+func writeReport(reporter report.IReport, opts Options, ws *workspace) int {
+	gradeDetails, err := ws.GradeDetails(opts.ToolList(), opts.Threshold())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if err := reporter.GenerateReport(gradeDetails, opts.ThresholdGrade, ws.Weigher); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(os.Stderr, "Report generated successfully!\n")
+	return ExitOK
+}
+
+// reportMarkdownCommand implements "report markdown".
+type reportMarkdownCommand struct{}
+
+func (c *reportMarkdownCommand) Name() string { return "markdown" }
+
+func (c *reportMarkdownCommand) Synopsis() string {
+	return "Write a Markdown summary for pull request comments and GitHub step summaries."
+}
+
+func (c *reportMarkdownCommand) Run(args []string) int {
+	var opts Options
+	var outputPath, summaryFile string
+	var worstFiles int
+	fs := newFlagSet("report markdown", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&outputPath, "output", report.DefaultMarkdownReportPath, "Path of the generated summary.")
+	fs.StringVar(&summaryFile, "summary-file", "", "Append the summary to this file instead of writing -output, e.g. \"$GITHUB_STEP_SUMMARY\".")
+	fs.IntVar(&worstFiles, "worst", report.DefaultWorstFiles, "Number of lowest-covered files to list.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	if summaryFile != "" {
+		return writeReport(report.NewMarkdownReport(summaryFile, worstFiles, true), opts, ws)
+	}
+	return writeReport(report.NewMarkdownReport(outputPath, worstFiles, false), opts, ws)
+}
+
+// reportBadgesCommand implements "report badges".
+type reportBadgesCommand struct{}
+
+func (c *reportBadgesCommand) Name() string { return "badges" }
+
+func (c *reportBadgesCommand) Synopsis() string {
+	return "Write SVG badges for the overall coverage and each tool's grade."
+}
+
+func (c *reportBadgesCommand) Run(args []string) int {
+	var opts Options
+	var outputDir string
+	fs := newFlagSet("report badges", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&outputDir, "output-dir", report.DefaultBadgeDir, "Directory the badges are written to.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	return writeReport(report.NewBadgeReport(outputDir), opts, ws)
+}
+
+// reportSarifCommand implements "report sarif".
+type reportSarifCommand struct {
+	version string
+}
+
+func (c *reportSarifCommand) Name() string { return "sarif" }
+
+func (c *reportSarifCommand) Synopsis() string {
+	return "Write unresolved code review findings as a SARIF 2.1.0 log."
+}
+
+func (c *reportSarifCommand) Run(args []string) int {
+	var opts Options
+	var outputPath string
+	fs := newFlagSet("report sarif", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&outputPath, "output", report.DefaultSARIFReportPath, "Path of the generated SARIF log.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	latest, err := ws.LatestHistories(opts.ToolList())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if err := report.NewSarifReport(outputPath, c.version).GenerateSarif(latest); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(os.Stderr, "Report generated successfully!\n")
+	return ExitOK
+}
+
+// reportCodeQualityCommand implements "report codequality".
+type reportCodeQualityCommand struct{}
+
+func (c *reportCodeQualityCommand) Name() string { return "codequality" }
+
+func (c *reportCodeQualityCommand) Synopsis() string {
+	return "Write below-threshold grades as a GitLab Code Quality (CodeClimate JSON) report."
+}
+
+func (c *reportCodeQualityCommand) Run(args []string) int {
+	var opts Options
+	var outputPath string
+	fs := newFlagSet("report codequality", c.Synopsis(), "")
+	opts.bindGradeFlags(fs)
+	fs.StringVar(&outputPath, "output", report.DefaultCodeQualityReportPath, "Path of the generated code quality report.")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	ws, err := loadWorkspaceFor(&opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	if err := opts.validateThresholdGrade(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+
+	latest, err := ws.LatestHistories(opts.ToolList())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return ExitError
+	}
+	codeQuality := report.NewCodeQualityReport(outputPath, ws.Scales)
+	if err := codeQuality.GenerateCodeQuality(latest, ws.Thresholds(opts.Threshold())); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(os.Stderr, "Report generated successfully!\n")
+	return ExitOK
+}
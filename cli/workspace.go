@@ -0,0 +1,461 @@
+package cli
+
+import (
+	"codeleft-cli/filter"
+	"codeleft-cli/read"
+	"codeleft-cli/schema"
+	"codeleft-cli/types"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// workspace is the history and configuration of the .codeLeft directory the CLI runs against.
+type workspace struct {
+	HistoryReader  read.CodeLeftReader
+	RepoRoot       string
+	Records        filter.Histories // Every record in history.ndjson as read, in file order
+	Histories      filter.Histories // Every mappable record in history.ndjson, with canonical paths
+	Unmapped       filter.Histories // Records whose path could not be mapped into the repository
+	Config         *types.Config
+	Scales         *filter.GradeScales        // The grade scale of every tool
+	Coverage       filter.ICoverageCalculator // Turns grades into coverage for assessments and reports
+	ThresholdRules []filter.ThresholdRule     // Per-tool and per-path thresholds from config.json
+	Weigher        filter.CoverageWeigher     // Weighs grades when coverage is averaged
+	Gitignore      []filter.IgnorePattern     // Patterns from the repository's .gitignore files
+	Codeleftignore []filter.IgnorePattern     // Patterns from .codeLeft/.codeleftignore
+	ExplainIgnores bool                       // Print the rule behind every excluded path
+	SkipMissing    bool                       // Exclude records for files missing from the working tree
+	StalePolicy    filter.StalePolicy         // How grades for changed files are treated; empty means off
+	ChangeSet      *filter.ChangeSet          // Files changed by the pull request; nil means the whole history
+	Unassessed     filter.UnassessedPolicy    // How source files without a grade count; empty means off
+}
+
+// loadWorkspace locates .codeLeft and reads both history.ndjson and config.json.
+func loadWorkspace() (*workspace, error) {
+	historyReader, err := read.NewHistoryReader()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing history reader: %w", err)
+	}
+
+	records, err := historyReader.ReadRecords()
+	if err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	scales, err := filter.NewGradeScalesFromConfig(config.GradeScales)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gradeScales in config.json: %w", err)
+	}
+	coverage, err := filter.NewCoverageCalculatorFromConfig(config.Coverage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coverage in config.json: %w", err)
+	}
+	thresholdRules, err := filter.NewThresholdRulesFromConfig(config.Thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid thresholds in config.json: %w", err)
+	}
+
+	histories := make(filter.Histories, 0, len(records))
+	for _, record := range records {
+		history := record.History
+		history.Line = record.Line
+		histories = append(histories, history)
+	}
+	repoRoot, canonicaliser, err := newPathCanonicaliser()
+	if err != nil {
+		return nil, err
+	}
+	recorded := histories
+	histories, unmapped := canonicaliser.Canonicalise(histories)
+	reportUnmapped(unmapped, repoRoot)
+	weigher, err := filter.NewCoverageWeigherFromConfig(config.Weighting, read.NewNonBlankLineCounter(repoRoot))
+	if err != nil {
+		return nil, fmt.Errorf("invalid weighting in config.json: %w", err)
+	}
+
+	ignoreFileReader, err := read.NewIgnoreFileReader()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing ignore file reader: %w", err)
+	}
+	gitignore, err := ignoreFileReader.ReadGitignorePatterns()
+	if err != nil {
+		return nil, err
+	}
+	codeleftignore, err := ignoreFileReader.ReadCodeleftignorePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace{
+		HistoryReader:  historyReader,
+		RepoRoot:       repoRoot,
+		Records:        recorded,
+		Histories:      histories,
+		Unmapped:       unmapped,
+		Config:         config,
+		Scales:         scales,
+		Coverage:       coverage,
+		ThresholdRules: thresholdRules,
+		Weigher:        weigher,
+		Gitignore:      gitignore,
+		Codeleftignore: codeleftignore,
+	}, nil
+}
+
+// maxReportedGrades caps how many unknown grades validateGrades lists.
+const maxReportedGrades = 20
+
+// validateGrades rejects histories with grades that are not on their tool's grade scale,
+// listing them by line so they can be fixed in history.ndjson or covered by a custom scale.
+// Only the histories a command works on are checked, so that an old record of an ignored
+// file or an unselected tool does not block it.
+func (w *workspace) validateGrades(histories filter.Histories) error {
+	historyPath := w.HistoryReader.HistoryPath()
+	unknown := []string{}
+	for _, history := range histories {
+		if !w.Scales.IsKnown(history.AssessingTool, history.Grade) {
+			unknown = append(unknown, fmt.Sprintf("  %s:%d: grade %q of %s for %s", historyPath, history.Line, history.Grade, history.AssessingTool, history.FilePath))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	if len(unknown) > maxReportedGrades {
+		unknown = append(unknown[:maxReportedGrades], fmt.Sprintf("  ... and %d more", len(unknown)-maxReportedGrades))
+	}
+	return fmt.Errorf("%s has grades that are not on their tool's grade scale (see gradeScales in config.json):\n%s", historyPath, strings.Join(unknown, "\n"))
+}
+
+// newPathCanonicaliser detects the repository root and creates the canonicaliser that maps
+// recorded paths onto it.
+func newPathCanonicaliser() (string, *filter.PathCanonicaliser, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	repoRoot := read.FindRepoRoot(cwd)
+	return repoRoot, filter.NewPathCanonicaliser(repoRoot, read.NewOSFileChecker(repoRoot)), nil
+}
+
+// canonicalPaths maps the path of every mappable record, as recorded, to its canonical path.
+func (w *workspace) canonicalPaths() map[string]string {
+	recordedPaths := make(map[int]string) // Keyed by line in history.ndjson
+	for _, record := range w.Records {
+		recordedPaths[record.Line] = record.FilePath
+	}
+	paths := make(map[string]string)
+	for _, history := range w.Histories {
+		paths[recordedPaths[history.Line]] = history.FilePath
+	}
+	return paths
+}
+
+// reportUnmapped lists, once per path, the records left out because their path could not be
+// mapped into the repository.
+func reportUnmapped(unmapped filter.Histories, repoRoot string) {
+	if len(unmapped) == 0 {
+		return
+	}
+	counts := make(map[string]int)
+	paths := []string{}
+	for _, history := range unmapped {
+		if counts[history.FilePath] == 0 {
+			paths = append(paths, history.FilePath)
+		}
+		counts[history.FilePath]++
+	}
+	sort.Strings(paths)
+
+	fmt.Fprintf(os.Stderr, "Warning: %d history record(s) could not be mapped to the repository at %s and were skipped:\n", len(unmapped), repoRoot)
+	for _, path := range paths {
+		fmt.Fprintf(os.Stderr, "  %s (%d record(s))\n", path, counts[path])
+	}
+}
+
+// loadWorkspaceFor loads the workspace for a command working on graded history and
+// completes opts with the defaults from config.json.
+func loadWorkspaceFor(opts *Options) (*workspace, error) {
+	stalePolicy, err := filter.ParseStalePolicy(opts.StalePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := loadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	opts.applyConfigDefaults(ws.Config)
+	ws.StalePolicy = stalePolicy
+	if ws.Unassessed, err = filter.ParseUnassessedPolicy(opts.Unassessed); err != nil {
+		return nil, err
+	}
+	if opts.ChangedSince != "" {
+		if err := ws.restrictToChanges(read.NewGitChangedFiles(ws.RepoRoot), opts.ChangedSince); err != nil {
+			return nil, err
+		}
+	}
+	ws.ExplainIgnores = opts.ExplainIgnores
+	ws.SkipMissing = opts.SkipMissing
+	return ws, nil
+}
+
+// restrictToChanges limits the workspace to the files changed since ref.
+func (w *workspace) restrictToChanges(provider read.ChangedFilesProvider, ref string) error {
+	paths, err := provider.ChangedFiles(ref)
+	if err != nil {
+		return err
+	}
+	w.ChangeSet = filter.NewChangeSet(paths)
+	fmt.Fprintf(os.Stderr, "Assessing %d file(s) changed since %s\n", len(w.ChangeSet.Paths()), ref)
+	return nil
+}
+
+// loadConfig reads config.json from the discovered .codeLeft directory. Problems found by
+// the config schema are printed as warnings; only unreadable JSON is an error.
+func loadConfig() (*types.Config, error) {
+	configReader, err := read.NewConfigReader(read.NewOSFileSystem())
+	if err != nil {
+		return nil, fmt.Errorf("error initializing config reader: %w", err)
+	}
+	data, err := configReader.ReadConfigBytes()
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+
+	problems, err := validateConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	configPath, _ := configReader.ResolveConfigPath()
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", formatProblem(configPath, problem))
+	}
+
+	config, err := read.DecodeConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %w", err)
+	}
+	warnDeprecatedKeys(config)
+	return config, nil
+}
+
+// warnDeprecatedKeys points out keys of config.json that are still read under an old name.
+func warnDeprecatedKeys(config *types.Config) {
+	if config.Security.UsesCweAlias() {
+		fmt.Fprintf(os.Stderr, "Warning: security.%s is deprecated, rename it to security.cweTop25\n", types.LegacyCweKey)
+	}
+}
+
+// validateConfig checks config.json against the embedded schema.
+func validateConfig(data []byte) ([]schema.Problem, error) {
+	validator, err := schema.NewConfigValidator()
+	if err != nil {
+		return nil, fmt.Errorf("error loading config schema: %w", err)
+	}
+	return validator.Validate(data), nil
+}
+
+// formatProblem renders a schema problem in the file:line:column form editors understand.
+func formatProblem(path string, problem schema.Problem) string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", path, problem.Line, problem.Column, problem.Path, problem.Message)
+}
+
+// LatestHistories returns the newest record per file and tool, restricted to the
+// requested tools and with the config's ignore rules applied. It fails if any of them has
+// a grade that is not on its tool's grade scale.
+func (w *workspace) LatestHistories(tools []string) (filter.Histories, error) {
+	histories := w.latestHistories(tools)
+	if err := w.validateGrades(histories); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// latestHistories is LatestHistories without the grade check, for commands that only show
+// the grades.
+func (w *workspace) latestHistories(tools []string) filter.Histories {
+	latestGradeFilter := filter.NewLatestGrades()
+	histories := latestGradeFilter.FilterLatestGrades(w.Histories)
+
+	toolFilter := filter.NewToolFilter(filter.NewToolCleaner())
+	histories = toolFilter.Filter(tools, histories)
+
+	if w.ChangeSet != nil {
+		histories = w.ChangeSet.Filter(histories)
+	}
+	histories = w.applyIgnoreRules(histories)
+	histories = w.applyExistenceFilter(histories)
+	return w.applyStalePolicy(histories)
+}
+
+// warnUnknownGrades prints the histories with grades that are not on their tool's grade
+// scale, for commands that only show the history.
+func (w *workspace) warnUnknownGrades(histories filter.Histories) {
+	if err := w.validateGrades(histories); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// applyIgnoreRules drops records matched by the ignore section of config.json, the
+// repository's .gitignore files or .codeLeft/.codeleftignore.
+func (w *workspace) applyIgnoreRules(histories filter.Histories) filter.Histories {
+	pathFilter := w.pathFilter()
+
+	if w.ExplainIgnores {
+		for _, exclusion := range pathFilter.Exclusions(histories) {
+			fmt.Fprintf(os.Stderr, "Ignored %s: %s\n", exclusion.Path, exclusion.Rule)
+		}
+	}
+	return pathFilter.Filter(histories)
+}
+
+// pathFilter combines every ignore rule that applies to the workspace.
+func (w *workspace) pathFilter() *filter.PathFilter {
+	ignore := w.Config.Ignore
+	return filter.NewPathFilter(
+		filter.NewIgnoreFileRule(ignore.Files),
+		filter.NewIgnoreFolderRule(ignore.Folders),
+		filter.NewIgnorePatternRuleFromPatterns(w.ignorePatterns()),
+	)
+}
+
+// ignorePatterns lists the gitignore-style patterns from lowest to highest precedence: the
+// .gitignore files, .codeLeft/.codeleftignore, then ignore.patterns of config.json, so that a
+// negation in a later source can re-include a file an earlier one ignores.
+func (w *workspace) ignorePatterns() []filter.IgnorePattern {
+	patterns := append([]filter.IgnorePattern{}, w.Gitignore...)
+	patterns = append(patterns, w.Codeleftignore...)
+	return append(patterns, filter.ParseConfigIgnorePatterns(w.Config.Ignore.Patterns)...)
+}
+
+// RequiredChangedPaths returns the changed source files that are not ignored, i.e. the
+// files that need a grade before a pull request passes.
+func (w *workspace) RequiredChangedPaths() []string {
+	pathFilter := w.pathFilter()
+	required := []string{}
+	for _, path := range w.ChangeSet.Paths() {
+		if w.sourceExtensions().Matches(path) && !pathFilter.IsIgnored(path) {
+			required = append(required, path)
+		}
+	}
+	return required
+}
+
+// sourceExtensions returns the extensions that mark source files, from config.json.
+func (w *workspace) sourceExtensions() read.SourceExtensions {
+	return read.NewSourceExtensions(w.Config.Sources.Extensions)
+}
+
+// unassessedDetails lists the source files in the working tree, within the ignore rules and
+// the change set, that have no grade in details for one of the tools.
+func (w *workspace) unassessedDetails(tools []string, details []filter.GradeDetails) []filter.GradeDetails {
+	if w.Unassessed == "" || w.Unassessed == filter.UnassessedPolicyOff {
+		return nil
+	}
+
+	files, err := read.NewSourceFileLister(w.RepoRoot, w.sourceExtensions()).ListSourceFiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list source files: %v\n", err)
+		return nil
+	}
+	pathFilter := w.pathFilter()
+	sourceFiles := []string{}
+	for _, file := range files {
+		if pathFilter.IsIgnored(file) || (w.ChangeSet != nil && !w.ChangeSet.Contains(file)) {
+			continue
+		}
+		sourceFiles = append(sourceFiles, file)
+	}
+
+	gaps := filter.FindUnassessed(sourceFiles, tools, details)
+	if len(gaps) > 0 {
+		fmt.Fprintf(os.Stderr, "Found %d unassessed file/tool pair(s) among %d source file(s)\n", len(gaps), len(sourceFiles))
+	}
+	return gaps
+}
+
+// applyExistenceFilter drops records for files that no longer exist in the working tree,
+// when SkipMissing is set, and reports how many were excluded.
+func (w *workspace) applyExistenceFilter(histories filter.Histories) filter.Histories {
+	if !w.SkipMissing {
+		return histories
+	}
+	present, missing := filter.NewExistenceFilter(read.NewOSFileChecker(w.RepoRoot)).Filter(histories)
+	if len(missing) == 0 {
+		return present
+	}
+
+	files := make(map[string]bool)
+	for _, history := range missing {
+		if w.ExplainIgnores && !files[history.FilePath] {
+			fmt.Fprintf(os.Stderr, "Ignored %s: not in the working tree\n", history.FilePath)
+		}
+		files[history.FilePath] = true
+	}
+	fmt.Fprintf(os.Stderr, "Excluded %d record(s) for %d file(s) no longer in the working tree\n", len(missing), len(files))
+	return present
+}
+
+// applyStalePolicy marks records whose file changed since it was graded. Stale records are
+// dropped under the exclude policy and listed under the others.
+func (w *workspace) applyStalePolicy(histories filter.Histories) filter.Histories {
+	if w.StalePolicy == "" || w.StalePolicy == filter.StalePolicyOff {
+		return histories
+	}
+	histories = filter.NewStaleDetector(read.NewSHA256FileHasher(w.RepoRoot)).Mark(histories)
+	fresh, stale := filter.SplitStale(histories)
+	if len(stale) == 0 {
+		return histories
+	}
+
+	if w.StalePolicy == filter.StalePolicyExclude {
+		if w.ExplainIgnores {
+			for _, history := range stale {
+				fmt.Fprintf(os.Stderr, "Ignored %s: %s grade is stale\n", history.FilePath, history.AssessingTool)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Excluded %d stale grade(s) for files changed since they were graded\n", len(stale))
+		return fresh
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %d grade(s) are stale because the file changed since it was graded:\n", len(stale))
+	for _, history := range stale {
+		fmt.Fprintf(os.Stderr, "  %s (%s, %s)\n", history.FilePath, history.AssessingTool, history.Grade)
+	}
+	return histories
+}
+
+// GradeDetails collects the latest grades for the requested tools, each with the threshold
+// it is held to and its coverage against that threshold, followed by the unassessed source
+// files when the unassessed policy asks for them.
+func (w *workspace) GradeDetails(tools []string, threshold filter.Threshold) ([]filter.GradeDetails, error) {
+	latest, err := w.LatestHistories(tools)
+	if err != nil {
+		return nil, err
+	}
+	return w.collectGradeDetails(latest, tools, threshold), nil
+}
+
+// collectGradeDetails is GradeDetails for latest histories the caller already has.
+func (w *workspace) collectGradeDetails(latest filter.Histories, tools []string, threshold filter.Threshold) []filter.GradeDetails {
+	thresholds := w.Thresholds(threshold)
+	gradeCollector := filter.NewGradeCollection(w.Scales, w.Coverage)
+	details := gradeCollector.CollectGrades(latest, thresholds)
+	gaps := w.unassessedDetails(tools, details)
+	for i := range gaps {
+		gaps[i].ApplyThreshold(thresholds.Resolve(gaps[i].Tool, gaps[i].FileName))
+	}
+	return append(details, gaps...)
+}
+
+// Thresholds resolves the threshold of each file/tool pair from the thresholds section of
+// config.json, falling back to defaults.
+func (w *workspace) Thresholds(defaults filter.Threshold) *filter.Thresholds {
+	return filter.NewThresholds(defaults, w.ThresholdRules)
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"codeleft-cli/filter"
+	"codeleft-cli/read"
+	"codeleft-cli/types"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newTestProject creates a git repository with a .codeLeft directory holding config and the
+// history lines, plus the given (empty) source files, and makes it the working directory
+// for the rest of the test.
+func newTestProject(t *testing.T, config string, history []string, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	contents := map[string]string{
+		".codeLeft/config.json":    config,
+		".codeLeft/history.ndjson": strings.Join(history, "\n"),
+	}
+	for _, file := range files {
+		contents[file] = ""
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range contents {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+	return root
+}
+
+// historyLine is a line of history.ndjson grading path for tool on the given day of 2025.
+func historyLine(path string, tool string, grade string, day int) string {
+	return fmt.Sprintf(`{"assessingTool":%q,"filePath":%q,"grade":%q,"timeStamp":"2025-01-%02dT10:00:00Z"}`, tool, path, grade, day)
+}
+
+// captureOutput runs run and returns what it printed to stdout and stderr.
+func captureOutput(t *testing.T, run func()) (stdout string, stderr string) {
+	t.Helper()
+	capture := func(target **os.File) func() string {
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := *target
+		*target = writer
+		var buf bytes.Buffer
+		done := make(chan struct{})
+		go func() {
+			io.Copy(&buf, reader)
+			close(done)
+		}()
+		return func() string {
+			writer.Close()
+			<-done
+			*target = original
+			return buf.String()
+		}
+	}
+	restoreStdout := capture(&os.Stdout)
+	restoreStderr := capture(&os.Stderr)
+	defer func() {
+		stdout, stderr = restoreStdout(), restoreStderr()
+	}()
+	run()
+	return
+}
+
+// fakeChangedFiles is a ChangedFilesProvider that needs no git repository.
+type fakeChangedFiles struct {
+	paths []string
+	err   error
+	ref   string // The ref it was last asked about
+}
+
+func (f *fakeChangedFiles) ChangedFiles(ref string) ([]string, error) {
+	f.ref = ref
+	return f.paths, f.err
+}
+
+// fakeHistoryReader is a CodeLeftReader over records held in memory.
+type fakeHistoryReader struct {
+	records []read.HistoryRecord
+}
+
+func (f *fakeHistoryReader) ReadHistory() (filter.Histories, error) {
+	histories := filter.Histories{}
+	for _, record := range f.records {
+		histories = append(histories, record.History)
+	}
+	return histories, nil
+}
+
+func (f *fakeHistoryReader) ReadRecords() ([]read.HistoryRecord, error) { return f.records, nil }
+
+func (f *fakeHistoryReader) HistoryPath() string { return ".codeLeft/history.ndjson" }
+
+func TestWorkspaceRestrictToChanges(t *testing.T) {
+	config := &types.Config{Ignore: types.IgnoreConfig{Patterns: []string{"gen/"}}}
+	ws := &workspace{Config: config}
+	provider := &fakeChangedFiles{paths: []string{"cli/assess.go", "README.md", "gen/api.go", "./cli/assess.go", "filter/model.go"}}
+
+	if err := ws.restrictToChanges(provider, "origin/main"); err != nil {
+		t.Fatalf("restrictToChanges: %v", err)
+	}
+	if provider.ref != "origin/main" {
+		t.Errorf("asked for changes since %q, expected origin/main", provider.ref)
+	}
+
+	histories := filter.Histories{{FilePath: "cli/assess.go"}, {FilePath: "cli/history.go"}, {FilePath: "filter/model.go"}}
+	if changed := ws.ChangeSet.Filter(histories); len(changed) != 2 {
+		t.Errorf("ChangeSet.Filter = %+v, expected the records of the two changed files", changed)
+	}
+
+	// README.md is not source code and gen/ is ignored, so neither needs a grade.
+	if required, expected := ws.RequiredChangedPaths(), []string{"cli/assess.go", "filter/model.go"}; !reflect.DeepEqual(required, expected) {
+		t.Errorf("RequiredChangedPaths = %v, expected %v", required, expected)
+	}
+}
+
+func TestWorkspaceRestrictToChangesError(t *testing.T) {
+	ws := &workspace{Config: &types.Config{}}
+	provider := &fakeChangedFiles{err: errors.New("unknown revision")}
+
+	if err := ws.restrictToChanges(provider, "nope"); err == nil {
+		t.Errorf("restrictToChanges succeeded, expected the provider's error")
+	}
+	if ws.ChangeSet != nil {
+		t.Errorf("ChangeSet = %+v, expected none after an error", ws.ChangeSet)
+	}
+}
@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BaselineVersion identifies the layout of baseline.json.
+const BaselineVersion = 1
+
+// Baseline is a snapshot of the latest grade of every file for every tool and of the average
+// coverage. The ratchet assessment fails when quality drops below it, rather than below an
+// absolute threshold.
+type Baseline struct {
+	Version        int             `json:"version"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	ThresholdGrade string          `json:"thresholdGrade"` // The grade coverage was calculated against
+	Tools          []string        `json:"tools"`
+	Coverage       float64         `json:"coverage"`
+	Weighting      string          `json:"weighting,omitempty"` // The weighting strategy coverage was averaged with; empty means uniform
+	Grades         []BaselineGrade `json:"grades"`              // Sorted by path, then tool
+}
+
+// BaselineGrade is the baseline grade of one file for one tool. Score is its index on the
+// built-in scale, which the ratchet compares.
+type BaselineGrade struct {
+	Path  string `json:"path"`
+	Tool  string `json:"tool"`
+	Grade string `json:"grade"`
+	Score int    `json:"score"`
+}
+
+// NewBaseline snapshots the graded details and the average coverage. Unassessed details
+// have no grade and are left out.
+func NewBaseline(details []GradeDetails, thresholdGrade string, tools []string, coverage float64, createdAt time.Time) *Baseline {
+	baseline := &Baseline{
+		Version:        BaselineVersion,
+		CreatedAt:      createdAt.UTC(),
+		ThresholdGrade: thresholdGrade,
+		Tools:          append([]string{}, tools...),
+		Coverage:       roundCoverage(coverage),
+		Grades:         []BaselineGrade{},
+	}
+	for _, detail := range details {
+		if detail.Unassessed {
+			continue
+		}
+		baseline.Grades = append(baseline.Grades, newBaselineGrade(detail))
+	}
+	baseline.sortGrades()
+	return baseline
+}
+
+func newBaselineGrade(detail GradeDetails) BaselineGrade {
+	return BaselineGrade{Path: detail.FileName, Tool: detail.Tool, Grade: detail.Grade, Score: detail.Score}
+}
+
+// Grade returns the baseline grade of the file for the tool, if it has one.
+func (b *Baseline) Grade(path string, tool string) (BaselineGrade, bool) {
+	for _, grade := range b.Grades {
+		if grade.Path == path && strings.EqualFold(grade.Tool, tool) {
+			return grade, true
+		}
+	}
+	return BaselineGrade{}, false
+}
+
+// CoverageDropped reports whether coverage, rounded as the baseline stores it, is below the
+// baseline coverage.
+func (b *Baseline) CoverageDropped(coverage float64) bool {
+	return roundCoverage(coverage) < b.Coverage
+}
+
+// Tighten raises the baseline to every grade in details that is better than its baseline
+// grade or has none yet, and to coverage when it is higher. It never lowers the baseline,
+// and reports whether anything changed.
+func (b *Baseline) Tighten(details []GradeDetails, coverage float64) bool {
+	indices := make(map[string]int)
+	for i, grade := range b.Grades {
+		indices[baselineKey(grade.Path, grade.Tool)] = i
+	}
+
+	changed := false
+	for _, detail := range details {
+		if detail.Unassessed {
+			continue
+		}
+		i, ok := indices[baselineKey(detail.FileName, detail.Tool)]
+		switch {
+		case !ok:
+			indices[baselineKey(detail.FileName, detail.Tool)] = len(b.Grades)
+			b.Grades = append(b.Grades, newBaselineGrade(detail))
+			changed = true
+		case detail.Score > b.Grades[i].Score:
+			b.Grades[i] = newBaselineGrade(detail)
+			changed = true
+		}
+	}
+	if rounded := roundCoverage(coverage); rounded > b.Coverage {
+		b.Coverage = rounded
+		changed = true
+	}
+	b.sortGrades()
+	return changed
+}
+
+func (b *Baseline) sortGrades() {
+	sort.SliceStable(b.Grades, func(i, j int) bool {
+		if b.Grades[i].Path != b.Grades[j].Path {
+			return b.Grades[i].Path < b.Grades[j].Path
+		}
+		return b.Grades[i].Tool < b.Grades[j].Tool
+	})
+}
+
+func baselineKey(path string, tool string) string {
+	return path + "|" + strings.ToLower(tool)
+}
+
+// roundCoverage keeps two decimals, so that a baseline read back compares equal to the
+// coverage it was written from.
+func roundCoverage(coverage float64) float64 {
+	return math.Round(coverage*100) / 100
+}
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func baselineDetail(path string, tool string, grade string, score int) GradeDetails {
+	return GradeDetails{FileName: path, Tool: tool, Grade: grade, Score: score}
+}
+
+func TestNewBaselineSkipsUnassessedAndSorts(t *testing.T) {
+	details := []GradeDetails{
+		baselineDetail("b.go", "SOLID", "B", 8),
+		baselineDetail("a.go", "SOLID", "C", 5),
+		{FileName: "c.go", Tool: "SOLID", Unassessed: true},
+	}
+	baseline := NewBaseline(details, "B", []string{"SOLID"}, 66.666, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if baseline.Version != BaselineVersion || baseline.Coverage != 66.67 {
+		t.Errorf("version %d, coverage %v, expected %d and 66.67", baseline.Version, baseline.Coverage, BaselineVersion)
+	}
+	if len(baseline.Grades) != 2 || baseline.Grades[0].Path != "a.go" || baseline.Grades[1].Path != "b.go" {
+		t.Errorf("grades = %+v, expected a.go then b.go", baseline.Grades)
+	}
+	if _, ok := baseline.Grade("a.go", "solid"); !ok {
+		t.Errorf("Grade(a.go, solid) not found, expected tools to match case-insensitively")
+	}
+	if _, ok := baseline.Grade("c.go", "SOLID"); ok {
+		t.Errorf("Grade(c.go, SOLID) found, expected unassessed files to be left out")
+	}
+}
+
+func TestBaselineCoverageDropped(t *testing.T) {
+	baseline := &Baseline{Coverage: 75.5}
+	tests := []struct {
+		coverage float64
+		expected bool
+	}{
+		{75.5, false},
+		{75.504, false}, // Rounds to the baseline
+		{75.496, false},
+		{75.49, true},
+		{80, false},
+	}
+	for _, tt := range tests {
+		if got := baseline.CoverageDropped(tt.coverage); got != tt.expected {
+			t.Errorf("CoverageDropped(%v) = %v, expected %v", tt.coverage, got, tt.expected)
+		}
+	}
+}
+
+func TestBaselineTighten(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  []GradeDetails
+		coverage float64
+		changed  bool
+		grades   map[string]string // Path to the expected SOLID grade
+		expected float64
+	}{
+		{
+			name:     "nothing better",
+			details:  []GradeDetails{baselineDetail("a.go", "SOLID", "D", 2)},
+			coverage: 40,
+			grades:   map[string]string{"a.go": "C"},
+			expected: 50,
+		},
+		{
+			name:     "better grade raises it",
+			details:  []GradeDetails{baselineDetail("a.go", "SOLID", "A", 12)},
+			coverage: 50,
+			changed:  true,
+			grades:   map[string]string{"a.go": "A"},
+			expected: 50,
+		},
+		{
+			name:     "new pair is added",
+			details:  []GradeDetails{baselineDetail("b.go", "SOLID", "F", 0)},
+			coverage: 50,
+			changed:  true,
+			grades:   map[string]string{"a.go": "C", "b.go": "F"},
+			expected: 50,
+		},
+		{
+			name:     "higher coverage raises it",
+			details:  []GradeDetails{},
+			coverage: 60.004,
+			changed:  true,
+			grades:   map[string]string{"a.go": "C"},
+			expected: 60,
+		},
+		{
+			name:     "unassessed pairs are not added",
+			details:  []GradeDetails{{FileName: "b.go", Tool: "SOLID", Unassessed: true}},
+			coverage: 50,
+			grades:   map[string]string{"a.go": "C"},
+			expected: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := &Baseline{Coverage: 50, Grades: []BaselineGrade{{Path: "a.go", Tool: "SOLID", Grade: "C", Score: 5}}}
+
+			if changed := baseline.Tighten(tt.details, tt.coverage); changed != tt.changed {
+				t.Errorf("Tighten changed = %v, expected %v", changed, tt.changed)
+			}
+			if baseline.Coverage != tt.expected {
+				t.Errorf("coverage = %v, expected %v", baseline.Coverage, tt.expected)
+			}
+			if len(baseline.Grades) != len(tt.grades) {
+				t.Fatalf("grades = %+v, expected %v", baseline.Grades, tt.grades)
+			}
+			for path, grade := range tt.grades {
+				if got, _ := baseline.Grade(path, "SOLID"); got.Grade != grade {
+					t.Errorf("grade of %s = %q, expected %q", path, got.Grade, grade)
+				}
+			}
+		})
+	}
+}
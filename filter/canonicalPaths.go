@@ -0,0 +1,130 @@
+package filter
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileExistenceChecker reports whether repository-relative, slash-separated paths
+// exist in the working tree.
+type FileExistenceChecker interface {
+	Exists(relativePath string) bool
+	DirectoryExists(relativePath string) bool
+}
+
+// windowsDrivePath matches absolute Windows paths once backslashes are converted, e.g. "C:/".
+var windowsDrivePath = regexp.MustCompile(`^[A-Za-z]:/`)
+
+// PathCanonicaliser rewrites the file paths recorded in history so that every record for
+// the same file uses the same repository-relative, slash-separated path.
+type PathCanonicaliser struct {
+	repoRoot string // Absolute repository root, slash-separated
+	repoName string // Base name of the repository root
+	checker  FileExistenceChecker
+}
+
+// NewPathCanonicaliser creates a PathCanonicaliser for the repository rooted at repoRoot.
+func NewPathCanonicaliser(repoRoot string, checker FileExistenceChecker) *PathCanonicaliser {
+	root := strings.TrimRight(filepath.ToSlash(repoRoot), "/")
+	return &PathCanonicaliser{
+		repoRoot: root,
+		repoName: path.Base(root),
+		checker:  checker,
+	}
+}
+
+// Canonicalise returns the histories with canonical file paths, and separately the records
+// whose path could not be mapped into the repository. Unmapped records are not returned in
+// the first slice.
+func (c *PathCanonicaliser) Canonicalise(histories Histories) (canonical Histories, unmapped Histories) {
+	knownPaths := c.knownRelativePaths(histories)
+
+	canonical = make(Histories, 0, len(histories))
+	for _, history := range histories {
+		canonicalPath, ok := c.canonicalPath(history.FilePath, knownPaths)
+		if !ok {
+			unmapped = append(unmapped, history)
+			continue
+		}
+		history.FilePath = canonicalPath
+		canonical = append(canonical, history)
+	}
+	return canonical, unmapped
+}
+
+// CanonicalPath maps a single recorded path into the repository.
+func (c *PathCanonicaliser) CanonicalPath(recorded string) (string, bool) {
+	return c.canonicalPath(recorded, map[string]bool{})
+}
+
+// knownRelativePaths collects the relative paths that records already use, which let a
+// foreign absolute path be mapped even after its file has been deleted.
+func (c *PathCanonicaliser) knownRelativePaths(histories Histories) map[string]bool {
+	known := make(map[string]bool)
+	for _, history := range histories {
+		normalized := normalizeRecordedPath(history.FilePath)
+		if !isAbsolutePath(normalized) && !strings.HasPrefix(normalized, "../") {
+			known[normalized] = true
+		}
+	}
+	return known
+}
+
+// canonicalPath applies, in order: relative paths as they are, absolute paths under this
+// repository's root, then the longest suffix of a foreign absolute path that exists in the
+// working tree or is already used by a relative record, then whatever follows a directory
+// named like the repository, and finally the longest suffix whose directory exists, which
+// maps files that have since been deleted.
+func (c *PathCanonicaliser) canonicalPath(recorded string, knownPaths map[string]bool) (string, bool) {
+	normalized := normalizeRecordedPath(recorded)
+	if normalized == "" || normalized == "." {
+		return "", false
+	}
+
+	if !isAbsolutePath(normalized) {
+		return normalized, !strings.HasPrefix(normalized, "../")
+	}
+
+	if c.repoRoot != "" && strings.HasPrefix(normalized, c.repoRoot+"/") {
+		return strings.TrimPrefix(normalized, c.repoRoot+"/"), true
+	}
+
+	parts := strings.Split(strings.TrimLeft(normalized, "/"), "/")
+	for i := 1; i < len(parts); i++ {
+		suffix := strings.Join(parts[i:], "/")
+		if knownPaths[suffix] || (c.checker != nil && c.checker.Exists(suffix)) {
+			return suffix, true
+		}
+	}
+
+	for i := len(parts) - 2; i >= 0; i-- {
+		if parts[i] == c.repoName {
+			return strings.Join(parts[i+1:], "/"), true
+		}
+	}
+
+	if c.checker != nil {
+		for i := 1; i < len(parts)-1; i++ {
+			if c.checker.DirectoryExists(strings.Join(parts[i:len(parts)-1], "/")) {
+				return strings.Join(parts[i:], "/"), true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeRecordedPath converts backslashes, strips "./" and cleans the path.
+func normalizeRecordedPath(recorded string) string {
+	normalized := strings.ReplaceAll(strings.TrimSpace(recorded), `\`, "/")
+	if normalized == "" {
+		return ""
+	}
+	return path.Clean(normalized)
+}
+
+// isAbsolutePath recognises Unix, UNC and Windows drive paths regardless of the host OS.
+func isAbsolutePath(p string) bool {
+	return strings.HasPrefix(p, "/") || windowsDrivePath.MatchString(p)
+}
@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeFileChecker is a working tree holding the listed files and their directories.
+type fakeFileChecker struct {
+	files map[string]bool
+}
+
+func newFakeFileChecker(files ...string) *fakeFileChecker {
+	checker := &fakeFileChecker{files: make(map[string]bool)}
+	for _, file := range files {
+		checker.files[file] = true
+	}
+	return checker
+}
+
+func (f *fakeFileChecker) Exists(relativePath string) bool {
+	return f.files[relativePath]
+}
+
+func (f *fakeFileChecker) DirectoryExists(relativePath string) bool {
+	for file := range f.files {
+		if strings.HasPrefix(file, relativePath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPathCanonicaliserCanonicalPath(t *testing.T) {
+	canonicaliser := NewPathCanonicaliser("/home/ci/work/codeleft-cli", newFakeFileChecker("cli/assess.go", "filter/model.go"))
+
+	tests := []struct {
+		name     string
+		recorded string
+		expected string
+		ok       bool
+	}{
+		{"relative path", "cli/assess.go", "cli/assess.go", true},
+		{"dot-slash prefix", "./cli/assess.go", "cli/assess.go", true},
+		{"backslashes", `cli\assess.go`, "cli/assess.go", true},
+		{"path is cleaned", "cli/../filter/model.go", "filter/model.go", true},
+		{"under the repository root", "/home/ci/work/codeleft-cli/cli/assess.go", "cli/assess.go", true},
+		{"foreign root, existing file", "/Users/dev/src/cli/assess.go", "cli/assess.go", true},
+		{"windows drive, existing file", `C:\Users\dev\project\filter\model.go`, "filter/model.go", true},
+		{"foreign root named like the repository", "/Users/dev/codeleft-cli/report/gone.go", "report/gone.go", true},
+		{"deleted file in an existing directory", "/Users/dev/other/cli/deleted.go", "cli/deleted.go", true},
+		{"outside the repository", "../elsewhere/main.go", "", false},
+		{"nothing maps", "/Users/dev/other/nowhere/main.go", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := canonicaliser.CanonicalPath(tt.recorded)
+			if ok != tt.ok || (ok && got != tt.expected) {
+				t.Errorf("CanonicalPath(%q) = %q, %v, expected %q, %v", tt.recorded, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPathCanonicaliserCanonicaliseUsesRecordedPaths(t *testing.T) {
+	canonicaliser := NewPathCanonicaliser("/home/ci/work/repo", newFakeFileChecker())
+	histories := Histories{
+		{FilePath: "pkg/removed.go", AssessingTool: "SOLID"},
+		{FilePath: "/Users/dev/checkout/pkg/removed.go", AssessingTool: "SOLID"},
+		{FilePath: "/Users/dev/checkout/unknown/file.go", AssessingTool: "SOLID"},
+	}
+
+	canonical, unmapped := canonicaliser.Canonicalise(histories)
+	if len(canonical) != 2 || canonical[0].FilePath != "pkg/removed.go" || canonical[1].FilePath != "pkg/removed.go" {
+		t.Errorf("canonical = %+v, expected both records at pkg/removed.go", canonical)
+	}
+	if len(unmapped) != 1 || unmapped[0].FilePath != "/Users/dev/checkout/unknown/file.go" {
+		t.Errorf("unmapped = %+v, expected the unknown/file.go record", unmapped)
+	}
+}
@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+)
+
+// ChangeSet is the set of repository-relative paths a pull request touches.
+type ChangeSet struct {
+	paths []string
+	index map[string]bool
+}
+
+// NewChangeSet creates a ChangeSet from paths, which are normalised like history paths.
+func NewChangeSet(paths []string) *ChangeSet {
+	changeSet := &ChangeSet{index: make(map[string]bool)}
+	for _, p := range paths {
+		normalized := normalizeRecordedPath(p)
+		if normalized == "" || changeSet.index[normalized] {
+			continue
+		}
+		changeSet.index[normalized] = true
+		changeSet.paths = append(changeSet.paths, normalized)
+	}
+	sort.Strings(changeSet.paths)
+	return changeSet
+}
+
+// Paths returns the changed paths in sorted order.
+func (c *ChangeSet) Paths() []string {
+	return c.paths
+}
+
+// Contains reports whether the canonical path was changed.
+func (c *ChangeSet) Contains(path string) bool {
+	return c.index[path]
+}
+
+// Filter keeps only the records for changed files.
+func (c *ChangeSet) Filter(histories Histories) Histories {
+	changed := Histories{}
+	for _, history := range histories {
+		if c.Contains(history.FilePath) {
+			changed = append(changed, history)
+		}
+	}
+	return changed
+}
+
+// MissingFreshGrades returns, for every path and tool, the combinations that have no grade
+// in details, as unassessed GradeDetails, or only a stale one, as that stale GradeDetails.
+func MissingFreshGrades(paths []string, tools []string, details []GradeDetails) []GradeDetails {
+	graded := make(map[string]GradeDetails)
+	for _, detail := range details {
+		if detail.Unassessed {
+			continue
+		}
+		graded[detail.FileName+"|"+strings.ToLower(detail.Tool)] = detail
+	}
+
+	missing := []GradeDetails{}
+	for _, path := range paths {
+		for _, tool := range tools {
+			tool = strings.TrimSpace(tool)
+			detail, found := graded[path+"|"+strings.ToLower(tool)]
+			switch {
+			case !found:
+				missing = append(missing, NewUnassessedGradeDetails(path, tool))
+			case detail.Stale:
+				missing = append(missing, detail)
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,56 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNewChangeSet(t *testing.T) {
+	changeSet := NewChangeSet([]string{"./b.go", `cli\a.go`, "b.go", "", "cli/../c.go"})
+
+	if expected := []string{"b.go", "c.go", "cli/a.go"}; !reflect.DeepEqual(changeSet.Paths(), expected) {
+		t.Errorf("Paths() = %v, expected %v", changeSet.Paths(), expected)
+	}
+	if !changeSet.Contains("cli/a.go") || changeSet.Contains("a.go") {
+		t.Errorf("Contains matched the wrong paths")
+	}
+
+	histories := Histories{{FilePath: "b.go"}, {FilePath: "d.go"}, {FilePath: "cli/a.go"}}
+	if filtered := changeSet.Filter(histories); len(filtered) != 2 || filtered[0].FilePath != "b.go" || filtered[1].FilePath != "cli/a.go" {
+		t.Errorf("Filter = %+v, expected the records of b.go and cli/a.go", filtered)
+	}
+}
+
+func TestMissingFreshGrades(t *testing.T) {
+	details := []GradeDetails{
+		{FileName: "fresh.go", Tool: "SOLID", Grade: "B"},
+		{FileName: "stale.go", Tool: "SOLID", Grade: "B", Stale: true},
+		{FileName: "gap.go", Tool: "SOLID", Unassessed: true},
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		tools    []string
+		expected []string // file|tool|unassessed
+	}{
+		{"fresh grade", []string{"fresh.go"}, []string{"SOLID"}, nil},
+		{"tools are case-insensitive", []string{"fresh.go"}, []string{" solid "}, nil},
+		{"stale grade", []string{"stale.go"}, []string{"SOLID"}, []string{"stale.go|SOLID|false"}},
+		{"never graded", []string{"new.go"}, []string{"SOLID"}, []string{"new.go|SOLID|true"}},
+		{"unassessed detail is not a grade", []string{"gap.go"}, []string{"SOLID"}, []string{"gap.go|SOLID|true"}},
+		{"missing tool", []string{"fresh.go"}, []string{"SOLID", "Clean-Code"}, []string{"fresh.go|Clean-Code|true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, detail := range MissingFreshGrades(tt.paths, tt.tools, details) {
+				got = append(got, fmt.Sprintf("%s|%s|%v", detail.FileName, detail.Tool, detail.Unassessed))
+			}
+			if len(got)+len(tt.expected) > 0 && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MissingFreshGrades = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CodeDiff is the set of line changes the IDE extension proposed along with a grade.
+type CodeDiff struct {
+	Changes []CodeChange `json:"changes"`
+}
+
+// UnmarshalJSON decodes the diff leniently: a codeDiff in an unexpected shape is treated as
+// empty rather than making the whole history record unreadable.
+func (d *CodeDiff) UnmarshalJSON(data []byte) error {
+	type plain CodeDiff
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		*d = CodeDiff{}
+		return nil
+	}
+	*d = CodeDiff(decoded)
+	return nil
+}
+
+// CodeChange is one proposed change, covering lines Start to End of the graded file.
+type CodeChange struct {
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Reason  string `json:"reason"`
+	OldCode string `json:"oldCode"`
+	NewCode string `json:"newCode"`
+}
+
+// ReviewTask is one finding of a detailed code review.
+type ReviewTask struct {
+	Done           bool   `json:"done"`
+	TitleTask      string `json:"titleTask"`
+	ViolatingCode  string `json:"violatingCode"`
+	CodeResolution string `json:"codeResolution"`
+	Severity       string `json:"severity"`
+	Rationale      string `json:"rationale"`
+	LineStart      int    `json:"lineStart"`
+	LineEnd        int    `json:"lineEnd"`
+	Status         string `json:"status"`
+}
+
+// Resolved reports whether the task was marked done or completed.
+func (t ReviewTask) Resolved() bool {
+	return t.Done || strings.EqualFold(t.Status, "completed")
+}
+
+// ReviewSummary returns the first line of the plain-text review or, for a detailed review,
+// its title. It is empty when the record has neither.
+func (h History) ReviewSummary() string {
+	review, ok := h.CodeReview["review"].(string)
+	if !ok {
+		detailedReview, _ := h.CodeReview["detailedReview"].(map[string]any)
+		review, _ = detailedReview["codeReviewTitle"].(string)
+	}
+	return strings.TrimSpace(strings.SplitN(review, "\n", 2)[0])
+}
+
+// ReviewTasks returns the tasks under codeReview.detailedReview. Records without a detailed
+// review, or with one in an unexpected shape, have no tasks.
+func (h History) ReviewTasks() []ReviewTask {
+	detailedReview, ok := h.CodeReview["detailedReview"]
+	if !ok {
+		return nil
+	}
+	encoded, err := json.Marshal(detailedReview)
+	if err != nil {
+		return nil
+	}
+
+	var review struct {
+		Tasks []ReviewTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(encoded, &review); err != nil {
+		return nil
+	}
+	return review.Tasks
+}
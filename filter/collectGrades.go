@@ -1,26 +1,30 @@
 package filter
 
 type CollectGrades interface {
-	CollectGrades(histories Histories, threshold string) []GradeDetails
+	CollectGrades(histories Histories, thresholds ThresholdResolver) []GradeDetails
 }
 
 type GradeCollection struct {
-	GradeCalculator GradeCalculator
+	GradeCalculator ToolGradeCalculator
 	CoverageCalculator ICoverageCalculator
 }
 
-func NewGradeCollection(calculator GradeCalculator, coverageCalculator ICoverageCalculator) CollectGrades {
+func NewGradeCollection(calculator ToolGradeCalculator, coverageCalculator ICoverageCalculator) CollectGrades {
 	return &GradeCollection{
 		GradeCalculator: calculator,
 		CoverageCalculator: coverageCalculator,
 	}
 }
 
-func (g *GradeCollection) CollectGrades(histories Histories, threshold string) []GradeDetails {
+func (g *GradeCollection) CollectGrades(histories Histories, thresholds ThresholdResolver) []GradeDetails {
 	gradeDetails := []GradeDetails{}
 	for _, history := range histories {
-		newDetails := NewGradeDetails(history.Grade, g.GradeCalculator.GradeNumericalValue(history.Grade), history.FilePath, history.AssessingTool, history.TimeStamp, g.CoverageCalculator)
-		newDetails.UpdateCoverage(g.GradeCalculator.GradeNumericalValue(threshold))
+		threshold := thresholds.Resolve(history.AssessingTool, history.FilePath)
+		newDetails := NewGradeDetails(history.Grade, g.GradeCalculator.ToolGradeValue(history.AssessingTool, history.Grade), history.FilePath, history.AssessingTool, history.TimeStamp, g.CoverageCalculator)
+		newDetails.ApplyThreshold(threshold)
+		newDetails.UpdateCoverage(g.GradeCalculator.GradeNumericalValue(threshold.Grade))
+		newDetails.Stale = history.Stale
+		newDetails.Review = history.ReviewSummary()
 
 		gradeDetails = append(gradeDetails, newDetails)
 
@@ -32,12 +36,9 @@ type GradeCalculator interface {
 	GradeNumericalValue(grade string) int
 }
 
-type GradeStringCalculator struct{}
-
-func NewGradeStringCalculator() GradeCalculator {
-	return &GradeStringCalculator{}
+// ToolGradeCalculator is a GradeCalculator that also knows the grade scale of each tool.
+// Its values are indices on the built-in scale, so they compare with the threshold grade.
+type ToolGradeCalculator interface {
+	GradeCalculator
+	ToolGradeValue(tool string, grade string) int
 }
-
-func (g *GradeStringCalculator) GradeNumericalValue(grade string) int {
-	return GetGradeIndex(grade)
-}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"fmt"
+)
+
+// Coverage strategies of the coverage section of config.json.
+const (
+	CoverageStrategySteps  = "steps"
+	CoverageStrategyLinear = "linear"
+	CoverageStrategyCapped = "capped"
+)
+
+// DefaultCoverageSteps is the step table of the IDE extension: 120% above the threshold,
+// 100% at it, then 90%, 80%, 70%, 50%, 30% and 10% for each grade further below.
+var DefaultCoverageSteps = []int{120, 100, 90, 80, 70, 50, 30, 10}
+
+// DefaultLinearCoverageStep is how many percentage points a grade is worth in the linear
+// strategy.
+const DefaultLinearCoverageStep = 10
+
+// ICoverageCalculator turns a grade into a coverage percentage. Score and threshold are
+// indices on the built-in scale. The assessments and the reports share one calculator, so
+// they always agree.
+type ICoverageCalculator interface {
+	CalculateCoverage(score int, threshold int) int
+}
+
+// StepCoverageCalculator looks the coverage up in a table: Steps[0] for any grade above the
+// threshold, Steps[1] at the threshold, Steps[2] one grade below it, and so on. The last
+// step applies to every grade further below.
+type StepCoverageCalculator struct {
+	Steps []int
+}
+
+func NewStepCoverageCalculator(steps []int) (ICoverageCalculator, error) {
+	if len(steps) < 2 {
+		return nil, fmt.Errorf("a step table needs at least two steps (above and at the threshold), got %d", len(steps))
+	}
+	return &StepCoverageCalculator{Steps: append([]int{}, steps...)}, nil
+}
+
+// NewDefaultCoverageCalculator returns the step table of the IDE extension.
+func NewDefaultCoverageCalculator() ICoverageCalculator {
+	return &StepCoverageCalculator{Steps: DefaultCoverageSteps}
+}
+
+func (c *StepCoverageCalculator) CalculateCoverage(score int, threshold int) int {
+	if score > threshold {
+		return c.Steps[0]
+	}
+	step := 1 + threshold - score
+	if step >= len(c.Steps) {
+		step = len(c.Steps) - 1
+	}
+	return c.Steps[step]
+}
+
+// LinearCoverageCalculator gives 100% at the threshold and moves Step points for every grade
+// above or below it, staying between 0% and Max.
+type LinearCoverageCalculator struct {
+	Step int
+	Max  int
+}
+
+// NewLinearCoverageCalculator caps the coverage at the default step table's bonus for grades
+// above the threshold.
+func NewLinearCoverageCalculator(step int) ICoverageCalculator {
+	return &LinearCoverageCalculator{Step: step, Max: DefaultCoverageSteps[0]}
+}
+
+func (c *LinearCoverageCalculator) CalculateCoverage(score int, threshold int) int {
+	coverage := 100 + (score-threshold)*c.Step
+	switch {
+	case coverage < 0:
+		return 0
+	case coverage > c.Max:
+		return c.Max
+	}
+	return coverage
+}
+
+// CappedCoverageCalculator limits the coverage of another calculator to Max, so that grades
+// above the threshold cannot make up for grades below it.
+type CappedCoverageCalculator struct {
+	Calculator ICoverageCalculator
+	Max        int
+}
+
+func NewCappedCoverageCalculator(calculator ICoverageCalculator, max int) ICoverageCalculator {
+	return &CappedCoverageCalculator{Calculator: calculator, Max: max}
+}
+
+func (c *CappedCoverageCalculator) CalculateCoverage(score int, threshold int) int {
+	return min(c.Calculator.CalculateCoverage(score, threshold), c.Max)
+}
+
+// NewCoverageCalculatorFromConfig builds the calculator selected by the coverage section of
+// config.json. The steps and capped strategies use the configured step table, or the
+// default one.
+func NewCoverageCalculatorFromConfig(config types.CoverageConfig) (ICoverageCalculator, error) {
+	steps := config.Steps
+	if len(steps) == 0 {
+		steps = DefaultCoverageSteps
+	}
+
+	switch config.Strategy {
+	case "", CoverageStrategySteps, CoverageStrategyCapped:
+		if config.LinearStep != 0 {
+			return nil, fmt.Errorf("linearStep only applies to the %s strategy", CoverageStrategyLinear)
+		}
+		calculator, err := NewStepCoverageCalculator(steps)
+		if err != nil {
+			return nil, err
+		}
+		if config.Strategy == CoverageStrategyCapped {
+			return NewCappedCoverageCalculator(calculator, 100), nil
+		}
+		return calculator, nil
+	case CoverageStrategyLinear:
+		if len(config.Steps) > 0 {
+			return nil, fmt.Errorf("steps do not apply to the %s strategy", CoverageStrategyLinear)
+		}
+		step := config.LinearStep
+		if step == 0 {
+			step = DefaultLinearCoverageStep
+		}
+		return NewLinearCoverageCalculator(step), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q: expected %s, %s or %s", config.Strategy, CoverageStrategySteps, CoverageStrategyLinear, CoverageStrategyCapped)
+	}
+}
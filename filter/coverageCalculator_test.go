@@ -0,0 +1,105 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"testing"
+)
+
+func TestCoverageCalculators(t *testing.T) {
+	steps := NewDefaultCoverageCalculator()
+	linear := NewLinearCoverageCalculator(DefaultLinearCoverageStep)
+	capped := NewCappedCoverageCalculator(NewDefaultCoverageCalculator(), 100)
+
+	tests := []struct {
+		name       string
+		calculator ICoverageCalculator
+		grade      string
+		threshold  string
+		expected   int
+	}{
+		{"steps above the threshold", steps, "A+", "B", 120},
+		{"steps one grade above", steps, "B+", "B", 120},
+		{"steps exactly on the threshold", steps, "B", "B", 100},
+		{"steps one below", steps, "B-", "B", 90},
+		{"steps on the last step", steps, "D", "B", 10},
+		{"steps past the last step", steps, "D-", "B", 10},
+		{"steps grade F", steps, "F", "A+", 10},
+		{"steps threshold F", steps, "F", "F", 100},
+
+		{"linear exactly on the threshold", linear, "B", "B", 100},
+		{"linear one above", linear, "B+", "B", 110},
+		{"linear exactly at the maximum", linear, "A-", "B", 120},
+		{"linear above the maximum", linear, "A+", "B", 120},
+		{"linear one below", linear, "B-", "B", 90},
+		{"linear grade F", linear, "F", "B", 20},
+		{"linear never below 0", linear, "F", "A+", 0},
+
+		{"capped above the threshold", capped, "A+", "B", 100},
+		{"capped exactly on the threshold", capped, "B", "B", 100},
+		{"capped below the threshold", capped, "B-", "B", 90},
+		{"capped grade F", capped, "F", "B", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.calculator.CalculateCoverage(gradeIndices[tt.grade], gradeIndices[tt.threshold])
+			if got != tt.expected {
+				t.Errorf("coverage of %s against %s = %d, expected %d", tt.grade, tt.threshold, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewStepCoverageCalculatorNeedsTwoSteps(t *testing.T) {
+	if _, err := NewStepCoverageCalculator([]int{100}); err == nil {
+		t.Errorf("a single step was accepted")
+	}
+	calculator, err := NewStepCoverageCalculator([]int{100, 100, 0})
+	if err != nil {
+		t.Fatalf("NewStepCoverageCalculator: %v", err)
+	}
+	if got := calculator.CalculateCoverage(gradeIndices["C"], gradeIndices["B"]); got != 0 {
+		t.Errorf("coverage past the last step = %d, expected the last step", got)
+	}
+}
+
+func TestNewCoverageCalculatorFromConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   types.CoverageConfig
+		above    int // Coverage of A+ against B
+		below    int // Coverage of C against B
+		hasError bool
+	}{
+		{"default", types.CoverageConfig{}, 120, 70, false},
+		{"steps", types.CoverageConfig{Strategy: "steps", Steps: []int{110, 100, 50}}, 110, 50, false},
+		{"capped", types.CoverageConfig{Strategy: "capped"}, 100, 70, false},
+		{"capped steps", types.CoverageConfig{Strategy: "capped", Steps: []int{150, 100, 60}}, 100, 60, false},
+		{"linear", types.CoverageConfig{Strategy: "linear"}, 120, 70, false},
+		{"linear step", types.CoverageConfig{Strategy: "linear", LinearStep: 25}, 120, 25, false},
+		{"too few steps", types.CoverageConfig{Steps: []int{100}}, 0, 0, true},
+		{"linear step for steps", types.CoverageConfig{Strategy: "steps", LinearStep: 5}, 0, 0, true},
+		{"steps for linear", types.CoverageConfig{Strategy: "linear", Steps: []int{120, 100}}, 0, 0, true},
+		{"unknown strategy", types.CoverageConfig{Strategy: "log"}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator, err := NewCoverageCalculatorFromConfig(tt.config)
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("config %+v was accepted", tt.config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewCoverageCalculatorFromConfig: %v", err)
+			}
+			threshold := gradeIndices["B"]
+			if above := calculator.CalculateCoverage(gradeIndices["A+"], threshold); above != tt.above {
+				t.Errorf("coverage above the threshold = %d, expected %d", above, tt.above)
+			}
+			if below := calculator.CalculateCoverage(gradeIndices["C"], threshold); below != tt.below {
+				t.Errorf("coverage below the threshold = %d, expected %d", below, tt.below)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Weighting strategies of the weighting section of config.json.
+const (
+	WeightingStrategyUniform     = "uniform"
+	WeightingStrategyLinesOfCode = "linesOfCode"
+	WeightingStrategyTools       = "tools"
+	WeightingStrategyPaths       = "paths"
+)
+
+// CoverageWeigher weighs grades when coverage is averaged. A grade of a file for a tool
+// weighs FileWeight times ToolWeight in the assessments. The reports average a file's tools
+// by ToolWeight, and its directory by FileWeight.
+type CoverageWeigher interface {
+	FileWeight(filePath string) float64
+	ToolWeight(tool string) float64
+	// DirectoryWeight is the weight of a directory in its parent, given the total weight of
+	// its children.
+	DirectoryWeight(childWeights float64) float64
+}
+
+// DetailWeight is the weight of one file/tool grade.
+func DetailWeight(weigher CoverageWeigher, detail GradeDetails) float64 {
+	return weigher.FileWeight(detail.FileName) * weigher.ToolWeight(detail.Tool)
+}
+
+// UniformWeigher gives every grade, file and directory the same weight: a directory's
+// coverage is the mean of its entries.
+type UniformWeigher struct{}
+
+func NewUniformWeigher() CoverageWeigher {
+	return &UniformWeigher{}
+}
+
+func (u *UniformWeigher) FileWeight(filePath string) float64 { return 1 }
+
+func (u *UniformWeigher) ToolWeight(tool string) float64 { return 1 }
+
+func (u *UniformWeigher) DirectoryWeight(childWeights float64) float64 { return 1 }
+
+// summedDirectoryWeight makes a directory weigh as much as the files beneath it.
+type summedDirectoryWeight struct{}
+
+func (summedDirectoryWeight) DirectoryWeight(childWeights float64) float64 { return childWeights }
+
+// LineCounter counts the lines of code of a file in the working tree.
+type LineCounter interface {
+	CountLines(filePath string) (int, error)
+}
+
+// LinesOfCodeWeigher weighs each file by its lines of code, so a 2,000-line module counts
+// for more than a 5-line helper. Files that cannot be read weigh as much as a single line.
+type LinesOfCodeWeigher struct {
+	summedDirectoryWeight
+	Counter LineCounter
+	lines   map[string]float64
+}
+
+func NewLinesOfCodeWeigher(counter LineCounter) CoverageWeigher {
+	return &LinesOfCodeWeigher{Counter: counter, lines: make(map[string]float64)}
+}
+
+func (l *LinesOfCodeWeigher) FileWeight(filePath string) float64 {
+	if lines, ok := l.lines[filePath]; ok {
+		return lines
+	}
+	lines, err := l.Counter.CountLines(filePath)
+	if err != nil || lines < 1 {
+		lines = 1
+	}
+	l.lines[filePath] = float64(lines)
+	return float64(lines)
+}
+
+func (l *LinesOfCodeWeigher) ToolWeight(tool string) float64 { return 1 }
+
+// ToolWeigher weighs each tool's grades by a configured weight; unlisted tools weigh 1.
+type ToolWeigher struct {
+	summedDirectoryWeight
+	Weights map[string]float64 // Keyed by lower-case tool name
+}
+
+func NewToolWeigher(weights map[string]float64) CoverageWeigher {
+	lower := make(map[string]float64)
+	for tool, weight := range weights {
+		lower[strings.ToLower(tool)] = weight
+	}
+	return &ToolWeigher{Weights: lower}
+}
+
+func (t *ToolWeigher) FileWeight(filePath string) float64 { return 1 }
+
+func (t *ToolWeigher) ToolWeight(tool string) float64 {
+	if weight, ok := t.Weights[strings.ToLower(tool)]; ok {
+		return weight
+	}
+	return 1
+}
+
+// PathWeight is the weight of the files matching a gitignore-style pattern.
+type PathWeight struct {
+	Pattern IgnorePattern
+	Weight  float64
+}
+
+// PathWeigher weighs each file by the last pattern that matches it, as in gitignore files;
+// files no pattern matches weigh 1.
+type PathWeigher struct {
+	summedDirectoryWeight
+	Weights []PathWeight
+}
+
+func NewPathWeigher(weights []PathWeight) CoverageWeigher {
+	return &PathWeigher{Weights: weights}
+}
+
+func (p *PathWeigher) FileWeight(filePath string) float64 {
+	weight := 1.0
+	for _, pathWeight := range p.Weights {
+		if pathWeight.Pattern.Match(filePath) {
+			weight = pathWeight.Weight
+		}
+	}
+	return weight
+}
+
+func (p *PathWeigher) ToolWeight(tool string) float64 { return 1 }
+
+// NewCoverageWeigherFromConfig builds the weigher selected by the weighting section of
+// config.json. counter reads the working tree for the linesOfCode strategy.
+func NewCoverageWeigherFromConfig(config types.WeightingConfig, counter LineCounter) (CoverageWeigher, error) {
+	strategy := config.Strategy
+	if strategy == "" {
+		strategy = WeightingStrategyUniform
+	}
+	if len(config.Tools) > 0 && strategy != WeightingStrategyTools {
+		return nil, fmt.Errorf("tools only apply to the %s strategy", WeightingStrategyTools)
+	}
+	if len(config.Paths) > 0 && strategy != WeightingStrategyPaths {
+		return nil, fmt.Errorf("paths only apply to the %s strategy", WeightingStrategyPaths)
+	}
+
+	switch strategy {
+	case WeightingStrategyUniform:
+		return NewUniformWeigher(), nil
+	case WeightingStrategyLinesOfCode:
+		return NewLinesOfCodeWeigher(counter), nil
+	case WeightingStrategyTools:
+		tools := make([]string, 0, len(config.Tools))
+		for tool := range config.Tools {
+			tools = append(tools, tool)
+		}
+		sort.Strings(tools)
+		for _, tool := range tools {
+			if config.Tools[tool] <= 0 {
+				return nil, fmt.Errorf("tool %q has weight %v, expected more than 0", tool, config.Tools[tool])
+			}
+		}
+		return NewToolWeigher(config.Tools), nil
+	case WeightingStrategyPaths:
+		weights := []PathWeight{}
+		for i, pathConfig := range config.Paths {
+			pattern, ok := ParseIgnorePattern(pathConfig.Path, "")
+			if !ok || pattern.Negate {
+				return nil, fmt.Errorf("path %d has invalid pattern %q", i+1, pathConfig.Path)
+			}
+			pattern.Origin = fmt.Sprintf("weighting.paths[%d]", i)
+			if pathConfig.Weight <= 0 {
+				return nil, fmt.Errorf("path %q has weight %v, expected more than 0", pathConfig.Path, pathConfig.Weight)
+			}
+			weights = append(weights, PathWeight{Pattern: pattern, Weight: pathConfig.Weight})
+		}
+		return NewPathWeigher(weights), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q: expected %s, %s, %s or %s", config.Strategy, WeightingStrategyUniform, WeightingStrategyLinesOfCode, WeightingStrategyTools, WeightingStrategyPaths)
+	}
+}
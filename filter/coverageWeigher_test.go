@@ -0,0 +1,101 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"errors"
+	"testing"
+)
+
+// fakeLineCounter is a LineCounter with fixed line counts that records how often it is asked.
+type fakeLineCounter struct {
+	lines map[string]int
+	calls int
+}
+
+func (f *fakeLineCounter) CountLines(filePath string) (int, error) {
+	f.calls++
+	lines, ok := f.lines[filePath]
+	if !ok {
+		return 0, errors.New("no such file")
+	}
+	return lines, nil
+}
+
+func TestCoverageWeighers(t *testing.T) {
+	counter := &fakeLineCounter{lines: map[string]int{"big.go": 2000, "empty.go": 0}}
+	pathWeigher, err := NewCoverageWeigherFromConfig(types.WeightingConfig{
+		Strategy: WeightingStrategyPaths,
+		Paths:    []types.PathWeight{{Path: "gen/", Weight: 0.5}, {Path: "gen/keep.go", Weight: 2}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCoverageWeigherFromConfig: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		weigher  CoverageWeigher
+		file     string
+		tool     string
+		expected float64 // DetailWeight of the file and tool
+	}{
+		{"uniform", NewUniformWeigher(), "big.go", "SOLID", 1},
+		{"uniform unknown tool and path", NewUniformWeigher(), "", "Unknown", 1},
+		{"lines of code", NewLinesOfCodeWeigher(counter), "big.go", "SOLID", 2000},
+		{"lines of code with zero lines", NewLinesOfCodeWeigher(counter), "empty.go", "SOLID", 1},
+		{"lines of code of an unreadable file", NewLinesOfCodeWeigher(counter), "gone.go", "SOLID", 1},
+		{"tools", NewToolWeigher(map[string]float64{"SOLID": 3}), "big.go", "SOLID", 3},
+		{"tools are case-insensitive", NewToolWeigher(map[string]float64{"SOLID": 3}), "big.go", "solid", 3},
+		{"unknown tool", NewToolWeigher(map[string]float64{"SOLID": 3}), "big.go", "Clean-Code", 1},
+		{"paths", pathWeigher, "gen/api.go", "SOLID", 0.5},
+		{"last matching path wins", pathWeigher, "gen/keep.go", "SOLID", 2},
+		{"unknown path", pathWeigher, "cli/assess.go", "SOLID", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetailWeight(tt.weigher, GradeDetails{FileName: tt.file, Tool: tt.tool})
+			if got != tt.expected {
+				t.Errorf("DetailWeight(%s, %s) = %v, expected %v", tt.file, tt.tool, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLinesOfCodeWeigherCountsOnce(t *testing.T) {
+	counter := &fakeLineCounter{lines: map[string]int{"a.go": 10}}
+	weigher := NewLinesOfCodeWeigher(counter)
+	weigher.FileWeight("a.go")
+	weigher.FileWeight("a.go")
+	weigher.FileWeight("gone.go")
+	weigher.FileWeight("gone.go")
+	if counter.calls != 2 {
+		t.Errorf("counted lines %d times, expected once per file", counter.calls)
+	}
+}
+
+func TestCoverageWeigherDirectoryWeight(t *testing.T) {
+	if got := NewUniformWeigher().DirectoryWeight(7); got != 1 {
+		t.Errorf("uniform directory weight = %v, expected 1", got)
+	}
+	for _, weigher := range []CoverageWeigher{NewLinesOfCodeWeigher(&fakeLineCounter{}), NewToolWeigher(nil), NewPathWeigher(nil)} {
+		if got := weigher.DirectoryWeight(7); got != 7 {
+			t.Errorf("%T directory weight = %v, expected the weight of its children", weigher, got)
+		}
+	}
+}
+
+func TestNewCoverageWeigherFromConfigErrors(t *testing.T) {
+	tests := map[string]types.WeightingConfig{
+		"unknown strategy":           {Strategy: "size"},
+		"tools for another strategy": {Tools: map[string]float64{"SOLID": 2}},
+		"paths for another strategy": {Strategy: WeightingStrategyTools, Paths: []types.PathWeight{{Path: "gen/", Weight: 2}}},
+		"zero tool weight":           {Strategy: WeightingStrategyTools, Tools: map[string]float64{"SOLID": 0}},
+		"zero path weight":           {Strategy: WeightingStrategyPaths, Paths: []types.PathWeight{{Path: "gen/", Weight: 0}}},
+		"negated path":               {Strategy: WeightingStrategyPaths, Paths: []types.PathWeight{{Path: "!gen/", Weight: 2}}},
+		"empty path":                 {Strategy: WeightingStrategyPaths, Paths: []types.PathWeight{{Path: "", Weight: 2}}},
+	}
+	for name, config := range tests {
+		if _, err := NewCoverageWeigherFromConfig(config, nil); err == nil {
+			t.Errorf("%s: config %+v was accepted", name, config)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package filter
+
+// ExistenceFilter drops records for files that are no longer in the working tree, so that
+// deleted and renamed files stop counting towards averages.
+type ExistenceFilter struct {
+	checker FileExistenceChecker
+}
+
+// NewExistenceFilter creates an ExistenceFilter that checks canonical paths with checker.
+func NewExistenceFilter(checker FileExistenceChecker) *ExistenceFilter {
+	return &ExistenceFilter{checker: checker}
+}
+
+// Filter splits histories into the records whose file exists and those whose file is missing.
+// Paths must already be canonical; each path is checked only once.
+func (f *ExistenceFilter) Filter(histories Histories) (present Histories, missing Histories) {
+	exists := make(map[string]bool)
+	present = make(Histories, 0, len(histories))
+	for _, history := range histories {
+		found, checked := exists[history.FilePath]
+		if !checked {
+			found = f.checker.Exists(history.FilePath)
+			exists[history.FilePath] = found
+		}
+		if found {
+			present = append(present, history)
+		} else {
+			missing = append(missing, history)
+		}
+	}
+	return present, missing
+}
@@ -0,0 +1,37 @@
+package filter
+
+import "testing"
+
+// countingFileChecker counts how often each path is checked.
+type countingFileChecker struct {
+	*fakeFileChecker
+	checks map[string]int
+}
+
+func (c *countingFileChecker) Exists(relativePath string) bool {
+	c.checks[relativePath]++
+	return c.fakeFileChecker.Exists(relativePath)
+}
+
+func TestExistenceFilterFilter(t *testing.T) {
+	checker := &countingFileChecker{fakeFileChecker: newFakeFileChecker("cli/a.go"), checks: make(map[string]int)}
+	histories := Histories{
+		{FilePath: "cli/a.go", AssessingTool: "SOLID"},
+		{FilePath: "cli/gone.go", AssessingTool: "SOLID"},
+		{FilePath: "cli/a.go", AssessingTool: "OWASP-TOP-10"},
+		{FilePath: "cli/gone.go", AssessingTool: "OWASP-TOP-10"},
+	}
+
+	present, missing := NewExistenceFilter(checker).Filter(histories)
+	if len(present) != 2 || present[0].FilePath != "cli/a.go" || present[1].AssessingTool != "OWASP-TOP-10" {
+		t.Errorf("present = %+v, expected both records of cli/a.go in order", present)
+	}
+	if len(missing) != 2 || missing[0].FilePath != "cli/gone.go" || missing[1].FilePath != "cli/gone.go" {
+		t.Errorf("missing = %+v, expected both records of cli/gone.go", missing)
+	}
+	for path, checks := range checker.checks {
+		if checks != 1 {
+			t.Errorf("%s checked %d times, expected once", path, checks)
+		}
+	}
+}
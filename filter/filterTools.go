@@ -34,9 +34,6 @@ func (t *ToolFilter) filterByTool(tool string, histories Histories) Histories {
 
 	for _, history := range histories {
 		if strings.ToUpper(history.AssessingTool) == strings.ToUpper(tool) {
-			history.CodeReview = map[string]any{}
-			history.GradingDetails = map[string]any{}
-
 			filteredHistories = append(filteredHistories, history)
 		}
 	}
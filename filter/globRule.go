@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnorePattern is a single gitignore-style pattern.
+// A pattern without a slash (other than a trailing one) matches a file or directory name at
+// any depth; any other pattern is anchored to Base. "**" matches any number of directories,
+// a trailing "/" only matches directories and a leading "!" re-includes what an earlier
+// pattern excluded.
+type IgnorePattern struct {
+	Source   string // The pattern as written
+	Origin   string // Where the pattern came from, e.g. ".gitignore:3"
+	Base     string // Directory the pattern is relative to, "" for the repository root
+	Negate   bool
+	DirOnly  bool
+	segments []string
+}
+
+// ParseIgnorePattern parses one line of gitignore syntax relative to base.
+// It returns false for blank lines and comments.
+func ParseIgnorePattern(line string, base string) (IgnorePattern, bool) {
+	pattern := strings.TrimRight(line, " \t\r")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return IgnorePattern{}, false
+	}
+
+	parsed := IgnorePattern{Source: pattern, Base: strings.Trim(filepath.ToSlash(base), "/")}
+	if strings.HasPrefix(pattern, "!") {
+		parsed.Negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\!`) || strings.HasPrefix(pattern, `\#`) {
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		parsed.DirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if pattern == "" {
+		return IgnorePattern{}, false
+	}
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	parsed.segments = strings.Split(pattern, "/")
+	if !anchored {
+		parsed.segments = append([]string{"**"}, parsed.segments...)
+	}
+	return parsed, true
+}
+
+// Match reports whether the pattern matches the file at filePath, either directly or
+// through one of its parent directories.
+func (p IgnorePattern) Match(filePath string) bool {
+	relative, ok := p.relativePath(filePath)
+	if !ok {
+		return false
+	}
+	parts := strings.Split(relative, "/")
+
+	// Directories are the proper prefixes of the path; the file itself is the full path.
+	if p.DirOnly {
+		return p.matchPrefixes(parts, len(parts)-1)
+	}
+	return p.matchPrefixes(parts, len(parts))
+}
+
+// MatchDirectory reports whether the pattern matches the directory at dirPath or one of its parents.
+func (p IgnorePattern) MatchDirectory(dirPath string) bool {
+	relative, ok := p.relativePath(dirPath)
+	if !ok {
+		return false
+	}
+	parts := strings.Split(relative, "/")
+	return p.matchPrefixes(parts, len(parts))
+}
+
+// matchPath reports whether the pattern matches exactly the path, a directory when isDir is
+// set, without looking at its parents.
+func (p IgnorePattern) matchPath(filePath string, isDir bool) bool {
+	if p.DirOnly && !isDir {
+		return false
+	}
+	relative, ok := p.relativePath(filePath)
+	if !ok {
+		return false
+	}
+	return matchSegments(p.segments, strings.Split(relative, "/"))
+}
+
+// matchPrefixes checks the pattern against parts[:1] up to parts[:last].
+func (p IgnorePattern) matchPrefixes(parts []string, last int) bool {
+	for i := 1; i <= last; i++ {
+		if matchSegments(p.segments, parts[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe names the pattern and where it came from.
+func (p IgnorePattern) Describe() string {
+	if p.Origin == "" {
+		return p.Source
+	}
+	return p.Origin + " (" + p.Source + ")"
+}
+
+// relativePath strips the pattern's base directory from filePath.
+func (p IgnorePattern) relativePath(filePath string) (string, bool) {
+	normalized := strings.TrimPrefix(filepath.ToSlash(filePath), "./")
+	if p.Base == "" {
+		return normalized, normalized != ""
+	}
+	if !strings.HasPrefix(normalized, p.Base+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(normalized, p.Base+"/"), true
+}
+
+// matchSegments matches path segments against pattern segments, where "**" spans
+// zero or more segments and every other segment uses path.Match syntax.
+func matchSegments(pattern []string, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for skip := 0; skip <= len(parts); skip++ {
+			if matchSegments(pattern[1:], parts[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], parts[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
+
+// IgnorePatternRule implements FilterRule for an ordered list of gitignore-style patterns.
+// As in gitignore, the last pattern that matches a path decides whether it is ignored, and a
+// path beneath an ignored directory stays ignored: a negation cannot re-include it.
+type IgnorePatternRule struct {
+	patterns []IgnorePattern
+}
+
+// NewIgnorePatternRule creates an IgnorePatternRule from the ignore.patterns of config.json,
+// which are relative to the repository root.
+func NewIgnorePatternRule(patterns []string) *IgnorePatternRule {
+	return NewIgnorePatternRuleFromPatterns(ParseConfigIgnorePatterns(patterns))
+}
+
+// ParseConfigIgnorePatterns parses the ignore.patterns of config.json.
+func ParseConfigIgnorePatterns(patterns []string) []IgnorePattern {
+	parsed := []IgnorePattern{}
+	for i, line := range patterns {
+		if pattern, ok := ParseIgnorePattern(line, ""); ok {
+			pattern.Origin = fmt.Sprintf("config.json ignore.patterns[%d]", i)
+			parsed = append(parsed, pattern)
+		}
+	}
+	return parsed
+}
+
+// NewIgnorePatternRuleFromPatterns creates an IgnorePatternRule from already parsed patterns,
+// e.g. those loaded from .gitignore files. Later patterns take precedence.
+func NewIgnorePatternRuleFromPatterns(patterns []IgnorePattern) *IgnorePatternRule {
+	return &IgnorePatternRule{patterns: patterns}
+}
+
+// Describe names the pattern that ignores the path.
+func (r *IgnorePatternRule) Describe(path string) string {
+	pattern, _ := r.MatchingPattern(path)
+	return pattern.Describe()
+}
+
+// Match checks whether the last pattern matching the path is an ignoring (non-negated) one.
+func (r *IgnorePatternRule) Match(path string) bool {
+	_, ignored := r.MatchingPattern(path)
+	return ignored
+}
+
+// MatchingPattern returns the pattern that ignores the path and whether there is one. The
+// path's directories are decided first, from the top, so the pattern ignoring a directory
+// also ignores everything beneath it.
+func (r *IgnorePatternRule) MatchingPattern(path string) (IgnorePattern, bool) {
+	parts := strings.Split(strings.TrimPrefix(filepath.ToSlash(path), "./"), "/")
+	for i := 1; i <= len(parts); i++ {
+		if pattern, ok := r.lastMatch(strings.Join(parts[:i], "/"), i < len(parts)); ok && !pattern.Negate {
+			return pattern, true
+		}
+	}
+	return IgnorePattern{}, false
+}
+
+// lastMatch returns the last pattern matching exactly the path.
+func (r *IgnorePatternRule) lastMatch(path string, isDir bool) (IgnorePattern, bool) {
+	for i := len(r.patterns) - 1; i >= 0; i-- {
+		if r.patterns[i].matchPath(path, isDir) {
+			return r.patterns[i], true
+		}
+	}
+	return IgnorePattern{}, false
+}
@@ -0,0 +1,112 @@
+package filter
+
+import "testing"
+
+func TestIgnorePatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		base     string
+		path     string
+		expected bool
+	}{
+		{"*_test.go", "", "cli/assess_test.go", true},
+		{"*_test.go", "", "assess_test.go", true},
+		{"*_test.go", "", "cli/assess.go", false},
+		{"/main.go", "", "main.go", true},
+		{"/main.go", "", "cmd/main.go", false},
+		{"vendor/**", "", "vendor/a/b/c.go", true},
+		{"vendor/**", "", "src/vendor/c.go", false},
+		{"**/gen/*.go", "", "a/b/gen/api.go", true},
+		{"**/gen/*.go", "", "gen/api.go", true},
+		{"internal/gen/*.pb.go", "", "internal/gen/api.pb.go", true},
+		{"internal/gen/*.pb.go", "", "internal/gen/sub/api.pb.go", false},
+		{"build/", "", "build/out.go", true},
+		{"build/", "", "build", false},
+		{"build", "", "build", true},
+		{"docs", "", "src/docs/readme.go", true},
+		{"*.go", "pkg", "pkg/a.go", true},
+		{"*.go", "pkg", "a.go", false},
+		{"/gen/", "pkg", "pkg/gen/a.go", true},
+		{"/gen/", "pkg", "gen/a.go", false},
+		{"a?c.go", "", "abc.go", true},
+		{"[ab].go", "", "c.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			pattern, ok := ParseIgnorePattern(tt.pattern, tt.base)
+			if !ok {
+				t.Fatalf("ParseIgnorePattern(%q) failed", tt.pattern)
+			}
+			if got := pattern.Match(tt.path); got != tt.expected {
+				t.Errorf("%q (base %q).Match(%q) = %v, expected %v", tt.pattern, tt.base, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseIgnorePattern(t *testing.T) {
+	tests := []struct {
+		line    string
+		ok      bool
+		negate  bool
+		dirOnly bool
+	}{
+		{"", false, false, false},
+		{"   ", false, false, false},
+		{"# comment", false, false, false},
+		{`\#file.go`, true, false, false},
+		{"!keep.go", true, true, false},
+		{`\!bang.go`, true, false, false},
+		{"build/", true, false, true},
+		{"!build/", true, true, true},
+		{"/", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			pattern, ok := ParseIgnorePattern(tt.line, "")
+			if ok != tt.ok || pattern.Negate != tt.negate || pattern.DirOnly != tt.dirOnly {
+				t.Errorf("ParseIgnorePattern(%q) = %+v, %v, expected ok %v, negate %v, dirOnly %v", tt.line, pattern, ok, tt.ok, tt.negate, tt.dirOnly)
+			}
+		})
+	}
+}
+
+func TestIgnorePatternRuleMatchingPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		ignored  bool
+		origin   string
+	}{
+		{"no pattern matches", []string{"*.md"}, "main.go", false, ""},
+		{"last match wins", []string{"*.go", "!main.go"}, "main.go", false, ""},
+		{"exclusion after negation", []string{"!main.go", "*.go"}, "main.go", true, "config.json ignore.patterns[1]"},
+		{"negation re-includes a file from a glob", []string{"gen/*", "!gen/keep.go"}, "gen/keep.go", false, ""},
+		{"negation cannot re-include beneath an excluded directory", []string{"gen/", "!gen/keep.go"}, "gen/keep.go", true, "config.json ignore.patterns[0]"},
+		{"negated directory is searched again", []string{"gen/", "!gen/"}, "gen/keep.go", false, ""},
+		{"excluded parent beats negated child directory", []string{"gen/", "!gen/sub/"}, "gen/sub/a.go", true, "config.json ignore.patterns[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, ignored := NewIgnorePatternRule(tt.patterns).MatchingPattern(tt.path)
+			if ignored != tt.ignored || pattern.Origin != tt.origin {
+				t.Errorf("MatchingPattern(%q) = %q, %v, expected %q, %v", tt.path, pattern.Origin, ignored, tt.origin, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestIgnorePatternRuleLaterSourceTakesPrecedence(t *testing.T) {
+	gitignore, _ := ParseIgnorePattern("*.pb.go", "")
+	gitignore.Origin = ".gitignore:1"
+	patterns := append([]IgnorePattern{gitignore}, ParseConfigIgnorePatterns([]string{"!api/keep.pb.go"})...)
+	rule := NewIgnorePatternRuleFromPatterns(patterns)
+
+	if rule.Match("api/keep.pb.go") {
+		t.Errorf("api/keep.pb.go is ignored, expected config.json to re-include it")
+	}
+	if got := rule.Describe("api/other.pb.go"); got != ".gitignore:1 (*.pb.go)" {
+		t.Errorf("Describe(api/other.pb.go) = %q, expected .gitignore:1 (*.pb.go)", got)
+	}
+}
@@ -2,45 +2,6 @@ package filter
 
 import "time"
 
-// ICoverageCalculator defines the interface for calculating coverage based on score and threshold.
-type ICoverageCalculator interface {
-	CalculateCoverage(score int, threshold int) int
-}
-
-// DefaultCoverageCalculator implements ICoverageCalculator using a rule-based approach.
-type DefaultCoverageCalculator struct{}
-
-func NewDefaultCoverageCalculator() ICoverageCalculator {
-	return &DefaultCoverageCalculator{}
-}
-
-// coverageRules defines the mapping from score difference to coverage percentage.
-// The rules are ordered from highest score (smallest or negative offset) to lowest
-// to ensure correct application of the first matching rule.
-var coverageRules = []struct {
-	MinScoreOffset int // score >= threshold + MinScoreOffset
-	Coverage       int
-}{
-	{MinScoreOffset: 1, Coverage: 120},
-	{MinScoreOffset: 0, Coverage: 100}, 
-	{MinScoreOffset: -1, Coverage: 90},
-	{MinScoreOffset: -2, Coverage: 80},
-	{MinScoreOffset: -3, Coverage: 70}, 
-	{MinScoreOffset: -4, Coverage: 50},
-	{MinScoreOffset: -5, Coverage: 30}, 
-}
-
-// CalculateCoverage determines the coverage percentage based on the given score and threshold.
-// It iterates through predefined rules to find the appropriate coverage.
-func (c *DefaultCoverageCalculator) CalculateCoverage(score int, threshold int) int {
-	for _, rule := range coverageRules {
-		if score >= threshold+rule.MinScoreOffset {
-			return rule.Coverage
-		}
-	}
-	return 10 // Default coverage for scores significantly below threshold (score < threshold - 5)
-}
-
 // GradeDetails holds information about a grade, including its calculated coverage.
 type GradeDetails struct {
 	Grade      string `json:"grade"`
@@ -49,6 +10,11 @@ type GradeDetails struct {
 	FileName   string `json:"fileName"`
 	Tool       string `json:"tool"`
 	Timestamp  time.Time `json:"timestamp"`
+	Stale      bool   `json:"stale,omitempty"` // The file changed since this grade was recorded
+	Unassessed bool   `json:"unassessed,omitempty"` // The tool never graded this file
+	Review     string `json:"-"` // First line of the review that came with the grade
+	ThresholdGrade   string `json:"thresholdGrade,omitempty"`   // The threshold this file/tool pair is held to
+	ThresholdPercent int    `json:"thresholdPercent,omitempty"` // 0 when coverage is not checked
 	calculator ICoverageCalculator // Injected dependency for coverage calculation
 }
 
@@ -64,6 +30,23 @@ func NewGradeDetails(grade string, score int, fileName string, tool string, time
 	}
 }
 
+// NewUnassessedGradeDetails creates the GradeDetails for a source file that the tool never
+// graded. It has no grade and counts as 0% coverage.
+func NewUnassessedGradeDetails(fileName string, tool string) GradeDetails {
+	return GradeDetails{
+		FileName:   fileName,
+		Tool:       tool,
+		Coverage:   0,
+		Unassessed: true,
+	}
+}
+
+// ApplyThreshold records the threshold the file/tool pair is held to.
+func (g *GradeDetails) ApplyThreshold(threshold Threshold) {
+	g.ThresholdGrade = threshold.Grade
+	g.ThresholdPercent = threshold.Percent
+}
+
 // UpdateCoverage calculates and sets the Coverage field of GradeDetails using the injected calculator.
 func (g *GradeDetails) UpdateCoverage(thresholdAsNum int) {
 	g.Coverage = g.calculator.CalculateCoverage(g.Score, thresholdAsNum)
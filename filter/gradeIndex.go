@@ -1,24 +1,43 @@
 package filter
 
 import (
-	"log"
 	"strings"
 )
 
-func GetGradeIndex(grade string) int {
-    // Use the same index values as the Javascript implementation
-    gradeIndices := map[string]int{
-        "A*": 11, "A+": 12, "A": 11, "A-": 10,
-        "B+": 9,  "B": 8,  "B-": 7,
-        "C+": 6,  "C": 5,  "C-": 4,
-        "D+": 3,  "D": 2,  "D-": 1,
-        "F":  0, // F is 0
-    }
-    // Ensure comparison is case-insensitive
-    index, ok := gradeIndices[strings.ToUpper(grade)]
-    if !ok {
-        log.Printf("Warning: Unrecognized grade '%s', treating as F (0)", grade)
-        return 0 // Default to 0 for unrecognized grades
-    }
-    return index
-}
\ No newline at end of file
+// gradeIndices uses the same index values as the Javascript implementation
+var gradeIndices = map[string]int{
+	"A*": 11, "A+": 12, "A": 11, "A-": 10,
+	"B+": 9, "B": 8, "B-": 7,
+	"C+": 6, "C": 5, "C-": 4,
+	"D+": 3, "D": 2, "D-": 1,
+	"F": 0, // F is 0
+}
+
+// GetGradeIndex returns the index of the grade on the built-in scale, and false for a grade
+// that is not on it. Grades of tools with their own scale go through GradeScales.
+func GetGradeIndex(grade string) (int, bool) {
+	// Ensure comparison is case-insensitive
+	index, ok := gradeIndices[strings.ToUpper(grade)]
+	return index, ok
+}
+
+// IsKnownGrade reports whether the grade exists on the grade scale.
+func IsKnownGrade(grade string) bool {
+	_, ok := GetGradeIndex(grade)
+	return ok
+}
+
+// gradeOrder lists the grades from lowest to highest index, with "A" standing for both
+// "A" and "A*".
+var gradeOrder = []string{"F", "D-", "D", "D+", "C-", "C", "C+", "B-", "B", "B+", "A-", "A", "A+"}
+
+// GradeAtIndex returns the grade with the given index, clamped to the ends of the scale.
+func GradeAtIndex(index int) string {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(gradeOrder) {
+		index = len(gradeOrder) - 1
+	}
+	return gradeOrder[index]
+}
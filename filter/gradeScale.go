@@ -0,0 +1,192 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BuiltinGradeScaleName names the built-in letter scale in config.json.
+const BuiltinGradeScaleName = "builtin"
+
+// GradeScale maps the grades a tool records onto the index of the built-in letter scale,
+// from 0 (F) to 12 (A+), so that grades from different scales can be compared with one
+// threshold.
+type GradeScale interface {
+	Index(grade string) (int, bool)
+}
+
+// BuiltinGradeScale is the letter scale of the IDE extension, where "A*" and "A" are equal.
+type BuiltinGradeScale struct{}
+
+func NewBuiltinGradeScale() GradeScale {
+	return &BuiltinGradeScale{}
+}
+
+func (b *BuiltinGradeScale) Index(grade string) (int, bool) {
+	index, ok := gradeIndices[strings.ToUpper(strings.TrimSpace(grade))]
+	return index, ok
+}
+
+// LabelGradeScale is an ordered list of labels, lowest first, spread evenly over the built-in
+// scale: with "fail" and "pass", "fail" is F and "pass" is A+.
+type LabelGradeScale struct {
+	indices map[string]int
+}
+
+func NewLabelGradeScale(labels []string) (GradeScale, error) {
+	if len(labels) < 2 {
+		return nil, fmt.Errorf("a label scale needs at least two labels, got %d", len(labels))
+	}
+	top := len(gradeOrder) - 1
+	indices := make(map[string]int)
+	for i, label := range labels {
+		key := strings.ToLower(strings.TrimSpace(label))
+		if _, duplicate := indices[key]; duplicate {
+			return nil, fmt.Errorf("label %q appears more than once", label)
+		}
+		indices[key] = int(math.Round(float64(i*top) / float64(len(labels)-1)))
+	}
+	return &LabelGradeScale{indices: indices}, nil
+}
+
+func (l *LabelGradeScale) Index(grade string) (int, bool) {
+	index, ok := l.indices[strings.ToLower(strings.TrimSpace(grade))]
+	return index, ok
+}
+
+// GradeRange maps numeric scores of at least Min onto the built-in grade index.
+type GradeRange struct {
+	Min   float64
+	Index int
+}
+
+// RangeGradeScale maps numeric scores onto built-in grades by range. A score takes the
+// grade of the range with the highest minimum it reaches; a score below every minimum is
+// not on the scale.
+type RangeGradeScale struct {
+	ranges []GradeRange // Highest minimum first
+}
+
+func NewRangeGradeScale(ranges []GradeRange) (GradeScale, error) {
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("a range scale needs at least one range")
+	}
+	sorted := append([]GradeRange{}, ranges...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Min > sorted[j].Min })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Min == sorted[i-1].Min {
+			return nil, fmt.Errorf("two ranges start at %v", sorted[i].Min)
+		}
+	}
+	return &RangeGradeScale{ranges: sorted}, nil
+}
+
+func (r *RangeGradeScale) Index(grade string) (int, bool) {
+	score, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(grade), "%"), 64)
+	if err != nil || math.IsNaN(score) {
+		return 0, false
+	}
+	for _, gradeRange := range r.ranges {
+		if score >= gradeRange.Min {
+			return gradeRange.Index, true
+		}
+	}
+	return 0, false
+}
+
+// GradeScales holds the grade scale of every tool. Tools without a scale of their own use
+// the built-in scale, which is also the scale of the threshold grade.
+type GradeScales struct {
+	Builtin GradeScale
+	Tools   map[string]GradeScale // Keyed by lower-case tool name
+}
+
+func NewGradeScales(tools map[string]GradeScale) *GradeScales {
+	scales := &GradeScales{Builtin: NewBuiltinGradeScale(), Tools: make(map[string]GradeScale)}
+	for tool, scale := range tools {
+		scales.Tools[strings.ToLower(tool)] = scale
+	}
+	return scales
+}
+
+// NewGradeScalesFromConfig builds the scales declared in the gradeScales section of
+// config.json and assigns them to their tools.
+func NewGradeScalesFromConfig(config types.GradeScalesConfig) (*GradeScales, error) {
+	declared := map[string]GradeScale{BuiltinGradeScaleName: NewBuiltinGradeScale()}
+	for name, scaleConfig := range config.Scales {
+		scale, err := newConfiguredGradeScale(scaleConfig)
+		if err != nil {
+			return nil, fmt.Errorf("grade scale %q: %w", name, err)
+		}
+		declared[name] = scale
+	}
+
+	tools := make(map[string]GradeScale)
+	for tool, name := range config.Tools {
+		scale, ok := declared[name]
+		if !ok {
+			return nil, fmt.Errorf("tool %q uses grade scale %q, which is not declared in gradeScales.scales", tool, name)
+		}
+		tools[tool] = scale
+	}
+	return NewGradeScales(tools), nil
+}
+
+func newConfiguredGradeScale(config types.GradeScaleConfig) (GradeScale, error) {
+	switch {
+	case len(config.Labels) > 0 && len(config.Ranges) > 0:
+		return nil, fmt.Errorf("declare either labels or ranges, not both")
+	case len(config.Labels) > 0:
+		return NewLabelGradeScale(config.Labels)
+	default:
+		ranges := []GradeRange{}
+		for _, gradeRange := range config.Ranges {
+			index, ok := gradeIndices[strings.ToUpper(gradeRange.Grade)]
+			if !ok {
+				return nil, fmt.Errorf("range from %v maps onto unknown grade %q", gradeRange.Min, gradeRange.Grade)
+			}
+			ranges = append(ranges, GradeRange{Min: gradeRange.Min, Index: index})
+		}
+		return NewRangeGradeScale(ranges)
+	}
+}
+
+// GradeScaleCalculator is a ToolGradeCalculator that can also tell which grades are on a
+// tool's scale, for callers that keep records with unknown grades instead of rejecting them.
+type GradeScaleCalculator interface {
+	ToolGradeCalculator
+	IsKnown(tool string, grade string) bool
+}
+
+// ScaleFor returns the scale the tool records its grades on.
+func (s *GradeScales) ScaleFor(tool string) GradeScale {
+	if scale, ok := s.Tools[strings.ToLower(tool)]; ok {
+		return scale
+	}
+	return s.Builtin
+}
+
+// IsKnown reports whether the grade is on the tool's scale.
+func (s *GradeScales) IsKnown(tool string, grade string) bool {
+	_, ok := s.ScaleFor(tool).Index(grade)
+	return ok
+}
+
+// GradeNumericalValue implements GradeCalculator on the built-in scale, the scale of the
+// threshold grade. Threshold grades are validated when the options and config.json are
+// loaded, so they are always on it.
+func (s *GradeScales) GradeNumericalValue(grade string) int {
+	index, _ := s.Builtin.Index(grade)
+	return index
+}
+
+// ToolGradeValue implements ToolGradeCalculator. Grades that are not on the tool's scale
+// count as F; workspaces reject them before grades are collected, or check IsKnown.
+func (s *GradeScales) ToolGradeValue(tool string, grade string) int {
+	index, _ := s.ScaleFor(tool).Index(grade)
+	return index
+}
@@ -0,0 +1,176 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"testing"
+)
+
+func TestGetGradeIndex(t *testing.T) {
+	tests := []struct {
+		grade string
+		index int
+		ok    bool
+	}{
+		{"A+", 12, true},
+		{"a*", 11, true},
+		{"b-", 7, true},
+		{"F", 0, true},
+		{"Z", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		index, ok := GetGradeIndex(tt.grade)
+		if index != tt.index || ok != tt.ok {
+			t.Errorf("GetGradeIndex(%q) = %d, %v, expected %d, %v", tt.grade, index, ok, tt.index, tt.ok)
+		}
+	}
+}
+
+func TestGradeAtIndex(t *testing.T) {
+	for grade := range gradeIndices {
+		index, _ := GetGradeIndex(grade)
+		if at := GradeAtIndex(index); at != grade && !(grade == "A*" && at == "A") {
+			t.Errorf("GradeAtIndex(%d) = %s, expected %s", index, at, grade)
+		}
+	}
+	if low, high := GradeAtIndex(-3), GradeAtIndex(20); low != "F" || high != "A+" {
+		t.Errorf("GradeAtIndex out of range = %s and %s, expected F and A+", low, high)
+	}
+}
+
+func TestLabelGradeScale(t *testing.T) {
+	scale, err := NewLabelGradeScale([]string{"fail", "Warn", "pass"})
+	if err != nil {
+		t.Fatalf("NewLabelGradeScale: %v", err)
+	}
+
+	tests := []struct {
+		label string
+		index int
+		ok    bool
+	}{
+		{"fail", 0, true},
+		{"warn", 6, true},
+		{" PASS ", 12, true},
+		{"A", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		index, ok := scale.Index(tt.label)
+		if index != tt.index || ok != tt.ok {
+			t.Errorf("Index(%q) = %d, %v, expected %d, %v", tt.label, index, ok, tt.index, tt.ok)
+		}
+	}
+
+	for name, labels := range map[string][]string{
+		"one label":       {"pass"},
+		"duplicate label": {"fail", "Fail"},
+	} {
+		if _, err := NewLabelGradeScale(labels); err == nil {
+			t.Errorf("%s: %v was accepted", name, labels)
+		}
+	}
+}
+
+func TestRangeGradeScale(t *testing.T) {
+	// Given out of order, with no range below 40.
+	scale, err := NewRangeGradeScale([]GradeRange{{Min: 40, Index: 2}, {Min: 90, Index: 12}, {Min: 70, Index: 8}})
+	if err != nil {
+		t.Fatalf("NewRangeGradeScale: %v", err)
+	}
+
+	tests := []struct {
+		score string
+		index int
+		ok    bool
+	}{
+		{"100", 12, true},
+		{"90", 12, true},
+		{"89.99", 8, true},
+		{"70", 8, true},
+		{" 75% ", 8, true},
+		{"40", 2, true},
+		{"39.9", 0, false},
+		{"-1", 0, false},
+		{"NaN", 0, false},
+		{"A", 0, false},
+	}
+	for _, tt := range tests {
+		index, ok := scale.Index(tt.score)
+		if index != tt.index || ok != tt.ok {
+			t.Errorf("Index(%q) = %d, %v, expected %d, %v", tt.score, index, ok, tt.index, tt.ok)
+		}
+	}
+
+	if _, err := NewRangeGradeScale(nil); err == nil {
+		t.Errorf("a scale without ranges was accepted")
+	}
+	if _, err := NewRangeGradeScale([]GradeRange{{Min: 50, Index: 8}, {Min: 50, Index: 2}}); err == nil {
+		t.Errorf("overlapping ranges starting at the same minimum were accepted")
+	}
+}
+
+func TestGradeScales(t *testing.T) {
+	labels, _ := NewLabelGradeScale([]string{"fail", "pass"})
+	scales := NewGradeScales(map[string]GradeScale{"Lint": labels})
+
+	tests := []struct {
+		name  string
+		tool  string
+		grade string
+		value int
+		known bool
+	}{
+		{"tool scale", "Lint", "pass", 12, true},
+		{"tools are case-insensitive", "LINT", "fail", 0, true},
+		{"letter grade off the tool scale", "Lint", "A", 0, false},
+		{"other tools use the built-in scale", "SOLID", "B", 8, true},
+		{"label off the built-in scale", "SOLID", "pass", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if known := scales.IsKnown(tt.tool, tt.grade); known != tt.known {
+				t.Errorf("IsKnown = %v, expected %v", known, tt.known)
+			}
+			if value := scales.ToolGradeValue(tt.tool, tt.grade); value != tt.value {
+				t.Errorf("ToolGradeValue = %d, expected %d", value, tt.value)
+			}
+		})
+	}
+	if value := scales.GradeNumericalValue("b+"); value != 9 {
+		t.Errorf("GradeNumericalValue(b+) = %d, expected the built-in index 9", value)
+	}
+}
+
+func TestNewGradeScalesFromConfig(t *testing.T) {
+	ranges := types.GradeScaleConfig{Ranges: []types.GradeRange{{Min: 80, Grade: "a"}, {Min: 0, Grade: "F"}}}
+	scales, err := NewGradeScalesFromConfig(types.GradeScalesConfig{
+		Scales: map[string]types.GradeScaleConfig{"percent": ranges},
+		Tools:  map[string]string{"Coverage": "percent", "SOLID": BuiltinGradeScaleName},
+	})
+	if err != nil {
+		t.Fatalf("NewGradeScalesFromConfig: %v", err)
+	}
+	if value := scales.ToolGradeValue("coverage", "85"); value != 11 {
+		t.Errorf("ToolGradeValue(coverage, 85) = %d, expected 11", value)
+	}
+	if !scales.IsKnown("SOLID", "C") {
+		t.Errorf("the builtin scale did not know C")
+	}
+
+	invalid := map[string]types.GradeScalesConfig{
+		"undeclared scale": {Tools: map[string]string{"SOLID": "percent"}},
+		"labels and ranges": {Scales: map[string]types.GradeScaleConfig{
+			"both": {Labels: []string{"fail", "pass"}, Ranges: ranges.Ranges},
+		}},
+		"range onto an unknown grade": {Scales: map[string]types.GradeScaleConfig{
+			"percent": {Ranges: []types.GradeRange{{Min: 0, Grade: "E"}}},
+		}},
+		"no labels or ranges": {Scales: map[string]types.GradeScaleConfig{"empty": {}}},
+	}
+	for name, config := range invalid {
+		if _, err := NewGradeScalesFromConfig(config); err == nil {
+			t.Errorf("%s: config was accepted", name)
+		}
+	}
+}
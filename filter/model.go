@@ -12,8 +12,11 @@ type History struct {
 	TimeStamp      time.Time      `json:"timeStamp"`
 	CodeReview     map[string]any `json:"codeReview"`
 	GradingDetails map[string]any `json:"gradingDetails"`
+	CodeDiff       CodeDiff       `json:"codeDiff"`
 	Hash           string         `json:"hash"`
 	Id 		  string         `json:"id"`
+	Stale          bool           `json:"-"` // Set by StaleDetector when the file changed since it was graded
+	Line           int            `json:"-"` // Line in history.ndjson, set when the history is read
 }
 
 type Histories []History
@@ -2,7 +2,9 @@ package filter
 
 import (
 	"codeleft-cli/types"
+	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -11,6 +13,13 @@ type FilterRule interface {
 	Match(path string) bool
 }
 
+// DescribedRule is implemented by rules that can say which of their entries matched a path,
+// so that exclusions can be explained to the user.
+type DescribedRule interface {
+	FilterRule
+	Describe(path string) string
+}
+
 // IgnoreFileRule implements FilterRule for ignoring specific files.
 // It encapsulates the logic for file path comparison, adhering to SRP.
 type IgnoreFileRule struct {
@@ -34,6 +43,11 @@ func (r *IgnoreFileRule) Match(path string) bool {
 	return false
 }
 
+// Describe names the ignored file entry matching the path.
+func (r *IgnoreFileRule) Describe(path string) string {
+	return "config.json ignore.files (" + filepath.ToSlash(path) + ")"
+}
+
 // IgnoreFolderRule implements FilterRule for ignoring files within specific folders.
 // It encapsulates the logic for folder path comparison, adhering to SRP.
 type IgnoreFolderRule struct {
@@ -62,6 +76,19 @@ func (r *IgnoreFolderRule) Match(path string) bool {
 	return false
 }
 
+// Describe names the ignored folder containing the path.
+func (r *IgnoreFolderRule) Describe(path string) string {
+	dirs := strings.Split(filepath.ToSlash(path), "/")
+	for _, dir := range dirs[:len(dirs)-1] {
+		for _, ignoredFolder := range r.ignoredFolders {
+			if dir == ignoredFolder {
+				return "config.json ignore.folders (" + ignoredFolder + ")"
+			}
+		}
+	}
+	return "config.json ignore.folders"
+}
+
 // PathFilter is responsible for orchestrating the filtering of file paths.
 // It depends on abstractions (FilterRule interface), adhering to DIP.
 type PathFilter struct {
@@ -90,6 +117,11 @@ func (pf *PathFilter) Filter(histories Histories) Histories {
 	return newHistories
 }
 
+// IsIgnored reports whether a single path is excluded by any of the configured rules.
+func (pf *PathFilter) IsIgnored(path string) bool {
+	return pf.isIgnored(path)
+}
+
 // isIgnored checks whether a given file path matches any of the configured filter rules.
 // This method's responsibility is solely to check against the rules, adhering to SRP.
 func (pf *PathFilter) isIgnored(path string) bool {
@@ -99,4 +131,39 @@ func (pf *PathFilter) isIgnored(path string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// Exclusion records a path removed by a PathFilter and the rule that removed it.
+type Exclusion struct {
+	Path string
+	Rule string
+}
+
+// Exclusions lists the distinct paths among the histories that the filter would remove,
+// together with a description of the first rule matching each of them.
+func (pf *PathFilter) Exclusions(histories Histories) []Exclusion {
+	exclusions := []Exclusion{}
+	seen := make(map[string]bool)
+	for _, history := range histories {
+		if seen[history.FilePath] {
+			continue
+		}
+		seen[history.FilePath] = true
+
+		for _, rule := range pf.rules {
+			if !rule.Match(history.FilePath) {
+				continue
+			}
+			description := fmt.Sprintf("%T", rule)
+			if described, ok := rule.(DescribedRule); ok {
+				description = described.Describe(history.FilePath)
+			}
+			exclusions = append(exclusions, Exclusion{Path: history.FilePath, Rule: description})
+			break
+		}
+	}
+	sort.Slice(exclusions, func(i, j int) bool {
+		return exclusions[i].Path < exclusions[j].Path
+	})
+	return exclusions
+}
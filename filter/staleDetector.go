@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileHasher hashes the current content of a repository-relative file.
+type FileHasher interface {
+	Hash(relativePath string) (string, error)
+}
+
+// StalePolicy decides what happens to grades recorded for an older version of a file.
+type StalePolicy string
+
+const (
+	StalePolicyWarn    StalePolicy = "warn"    // Count stale grades, but list them
+	StalePolicyFail    StalePolicy = "fail"    // Fail the assessment if any grade is stale
+	StalePolicyExclude StalePolicy = "exclude" // Leave stale grades out entirely
+	StalePolicyOff     StalePolicy = "off"     // Do not hash files at all
+)
+
+// ParseStalePolicy validates a policy name.
+func ParseStalePolicy(name string) (StalePolicy, error) {
+	policy := StalePolicy(strings.ToLower(strings.TrimSpace(name)))
+	switch policy {
+	case StalePolicyWarn, StalePolicyFail, StalePolicyExclude, StalePolicyOff:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown stale policy %q: expected warn, fail, exclude or off", name)
+}
+
+// StaleDetector compares the hash recorded with each grade against the file on disk.
+type StaleDetector struct {
+	hasher FileHasher
+}
+
+// NewStaleDetector creates a StaleDetector that hashes files with hasher.
+func NewStaleDetector(hasher FileHasher) *StaleDetector {
+	return &StaleDetector{hasher: hasher}
+}
+
+// Mark returns the histories with Stale set on every record whose hash no longer matches
+// its file. Records without a hash, and files that cannot be read, are never stale.
+// Paths must already be canonical; each file is hashed only once.
+func (d *StaleDetector) Mark(histories Histories) Histories {
+	hashes := make(map[string]string)
+	marked := make(Histories, 0, len(histories))
+	for _, history := range histories {
+		current, hashed := hashes[history.FilePath]
+		if !hashed {
+			current, _ = d.hasher.Hash(history.FilePath)
+			hashes[history.FilePath] = current
+		}
+		history.Stale = history.Hash != "" && current != "" && !strings.EqualFold(history.Hash, current)
+		marked = append(marked, history)
+	}
+	return marked
+}
+
+// SplitStale separates stale records from fresh ones.
+func SplitStale(histories Histories) (fresh Histories, stale Histories) {
+	fresh = make(Histories, 0, len(histories))
+	for _, history := range histories {
+		if history.Stale {
+			stale = append(stale, history)
+		} else {
+			fresh = append(fresh, history)
+		}
+	}
+	return fresh, stale
+}
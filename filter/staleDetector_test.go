@@ -0,0 +1,83 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeHasher hashes files from a fixed table and counts how often each is hashed.
+type fakeHasher struct {
+	hashes map[string]string
+	calls  map[string]int
+}
+
+func (f *fakeHasher) Hash(relativePath string) (string, error) {
+	f.calls[relativePath]++
+	hash, ok := f.hashes[relativePath]
+	if !ok {
+		return "", errors.New("no such file")
+	}
+	return hash, nil
+}
+
+func TestStaleDetectorMark(t *testing.T) {
+	hasher := &fakeHasher{hashes: map[string]string{"a.go": "abc123", "b.go": "def456"}, calls: make(map[string]int)}
+	histories := Histories{
+		{FilePath: "a.go", AssessingTool: "SOLID", Hash: "abc123"},
+		{FilePath: "a.go", AssessingTool: "OWASP-TOP-10", Hash: "ABC123"},
+		{FilePath: "b.go", AssessingTool: "SOLID", Hash: "abc123"},
+		{FilePath: "b.go", AssessingTool: "OWASP-TOP-10"},
+		{FilePath: "gone.go", AssessingTool: "SOLID", Hash: "abc123"},
+	}
+	expected := []bool{
+		false, // Same content
+		false, // Hashes compare case-insensitively
+		true,  // Changed since it was graded
+		false, // No recorded hash to compare
+		false, // Cannot be read
+	}
+
+	marked := NewStaleDetector(hasher).Mark(histories)
+	if len(marked) != len(expected) {
+		t.Fatalf("Mark returned %d records, expected %d", len(marked), len(expected))
+	}
+	for i, history := range marked {
+		if history.Stale != expected[i] {
+			t.Errorf("record %d (%s, %s): stale %v, expected %v", i, history.FilePath, history.AssessingTool, history.Stale, expected[i])
+		}
+	}
+	if histories[2].Stale {
+		t.Errorf("Mark changed the records it was given")
+	}
+	for path, calls := range hasher.calls {
+		if calls != 1 {
+			t.Errorf("%s hashed %d times, expected once", path, calls)
+		}
+	}
+
+	fresh, stale := SplitStale(marked)
+	if len(fresh) != 4 || len(stale) != 1 || stale[0].FilePath != "b.go" {
+		t.Errorf("SplitStale = %d fresh, %+v stale, expected the SOLID grade of b.go to be stale", len(fresh), stale)
+	}
+}
+
+func TestParseStalePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected StalePolicy
+		hasError bool
+	}{
+		{"warn", StalePolicyWarn, false},
+		{" FAIL ", StalePolicyFail, false},
+		{"exclude", StalePolicyExclude, false},
+		{"off", StalePolicyOff, false},
+		{"", "", true},
+		{"ignore", "", true},
+	}
+	for _, tt := range tests {
+		policy, err := ParseStalePolicy(tt.name)
+		if policy != tt.expected || (err != nil) != tt.hasError {
+			t.Errorf("ParseStalePolicy(%q) = %q, %v, expected %q and an error: %v", tt.name, policy, err, tt.expected, tt.hasError)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"fmt"
+	"strings"
+)
+
+// Threshold is the grade, and the coverage percentage, that a file's grade for a tool must
+// reach. A Percent of 0 leaves coverage unchecked.
+type Threshold struct {
+	Grade   string
+	Percent int
+}
+
+// ThresholdResolver finds the threshold of a file/tool pair.
+type ThresholdResolver interface {
+	Resolve(tool string, filePath string) Threshold
+}
+
+// ThresholdRule overrides the threshold grade, percentage or both for a tool, for the files
+// matching a path pattern, or for a tool within those files. An empty Grade or a zero
+// Percent leaves that part to a less specific rule.
+type ThresholdRule struct {
+	Tool    string
+	Path    *IgnorePattern // nil matches every file
+	Grade   string
+	Percent int
+}
+
+// matches reports whether the rule applies to the file/tool pair.
+func (r ThresholdRule) matches(tool string, filePath string) bool {
+	if r.Tool != "" && !strings.EqualFold(r.Tool, tool) {
+		return false
+	}
+	return r.Path == nil || r.Path.Match(filePath)
+}
+
+// specificity ranks rules: a tool within a path beats a path, which beats a tool. Between
+// two path patterns, the one with more literal characters is more specific.
+func (r ThresholdRule) specificity() (int, int) {
+	rank := 0
+	if r.Tool != "" {
+		rank = 1
+	}
+	if r.Path == nil {
+		return rank, 0
+	}
+	literal := len(strings.NewReplacer("*", "", "?", "", "[", "", "]", "").Replace(r.Path.Source))
+	return rank + 2, literal
+}
+
+// moreSpecific reports whether r takes precedence over other.
+func (r ThresholdRule) moreSpecific(other ThresholdRule) bool {
+	rank, literal := r.specificity()
+	otherRank, otherLiteral := other.specificity()
+	if rank != otherRank {
+		return rank > otherRank
+	}
+	return literal >= otherLiteral // On a tie the later rule wins
+}
+
+// Thresholds resolves the threshold of each file/tool pair from the most specific matching
+// rules, falling back to Default. The grade and the percentage are resolved separately.
+type Thresholds struct {
+	Default Threshold
+	Rules   []ThresholdRule
+}
+
+func NewThresholds(defaults Threshold, rules []ThresholdRule) *Thresholds {
+	return &Thresholds{Default: defaults, Rules: rules}
+}
+
+// NewThresholdRulesFromConfig parses the thresholds section of config.json.
+func NewThresholdRulesFromConfig(config []types.ThresholdRule) ([]ThresholdRule, error) {
+	rules := []ThresholdRule{}
+	for i, ruleConfig := range config {
+		rule := ThresholdRule{Tool: strings.TrimSpace(ruleConfig.Tool), Grade: strings.TrimSpace(ruleConfig.Grade), Percent: ruleConfig.Percent}
+		if rule.Tool == "" && strings.TrimSpace(ruleConfig.Path) == "" {
+			return nil, fmt.Errorf("rule %d needs a tool, a path or both", i+1)
+		}
+		if rule.Grade == "" && rule.Percent == 0 {
+			return nil, fmt.Errorf("rule %d sets neither a grade nor a percent", i+1)
+		}
+		if rule.Grade != "" && !IsKnownGrade(rule.Grade) {
+			return nil, fmt.Errorf("rule %d has unknown grade %q", i+1, rule.Grade)
+		}
+		if rule.Percent < 0 {
+			return nil, fmt.Errorf("rule %d has negative percent %d", i+1, rule.Percent)
+		}
+		if strings.TrimSpace(ruleConfig.Path) != "" {
+			pattern, ok := ParseIgnorePattern(ruleConfig.Path, "")
+			if !ok || pattern.Negate {
+				return nil, fmt.Errorf("rule %d has invalid path %q", i+1, ruleConfig.Path)
+			}
+			pattern.Origin = fmt.Sprintf("thresholds[%d]", i)
+			rule.Path = &pattern
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (t *Thresholds) Resolve(tool string, filePath string) Threshold {
+	threshold := t.Default
+	var gradeRule, percentRule *ThresholdRule
+	for i := range t.Rules {
+		rule := &t.Rules[i]
+		if !rule.matches(tool, filePath) {
+			continue
+		}
+		if rule.Grade != "" && (gradeRule == nil || rule.moreSpecific(*gradeRule)) {
+			gradeRule = rule
+		}
+		if rule.Percent != 0 && (percentRule == nil || rule.moreSpecific(*percentRule)) {
+			percentRule = rule
+		}
+	}
+	if gradeRule != nil {
+		threshold.Grade = gradeRule.Grade
+	}
+	if percentRule != nil {
+		threshold.Percent = percentRule.Percent
+	}
+	return threshold
+}
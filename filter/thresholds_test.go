@@ -0,0 +1,79 @@
+package filter
+
+import (
+	"codeleft-cli/types"
+	"strings"
+	"testing"
+)
+
+func TestThresholdsResolve(t *testing.T) {
+	rules, err := NewThresholdRulesFromConfig([]types.ThresholdRule{
+		{Tool: "SOLID", Grade: "B"},
+		{Path: "legacy/", Grade: "D", Percent: 40},
+		{Path: "legacy/core/", Grade: "C"},
+		{Tool: "solid", Path: "legacy/", Grade: "D-"},
+		{Tool: "Complexity", Percent: 90},
+	})
+	if err != nil {
+		t.Fatalf("NewThresholdRulesFromConfig: %v", err)
+	}
+	thresholds := NewThresholds(Threshold{Grade: "A", Percent: 80}, rules)
+
+	tests := []struct {
+		name     string
+		tool     string
+		path     string
+		expected Threshold
+	}{
+		{"no rule matches", "OWASP-TOP-10", "main.go", Threshold{Grade: "A", Percent: 80}},
+		{"tool rule", "SOLID", "main.go", Threshold{Grade: "B", Percent: 80}},
+		{"tool names are case-insensitive", "solid", "main.go", Threshold{Grade: "B", Percent: 80}},
+		{"path rule beats default", "OWASP-TOP-10", "legacy/db.go", Threshold{Grade: "D", Percent: 40}},
+		{"longer path beats shorter path", "OWASP-TOP-10", "legacy/core/db.go", Threshold{Grade: "C", Percent: 40}},
+		{"tool within path beats path", "SOLID", "legacy/core/db.go", Threshold{Grade: "D-", Percent: 40}},
+		{"grade and percent resolve separately", "Complexity", "main.go", Threshold{Grade: "A", Percent: 90}},
+		{"path percent beats tool percent", "Complexity", "legacy/db.go", Threshold{Grade: "D", Percent: 40}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thresholds.Resolve(tt.tool, tt.path); got != tt.expected {
+				t.Errorf("Resolve(%q, %q) = %+v, expected %+v", tt.tool, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestThresholdsResolveLaterRuleWinsTie(t *testing.T) {
+	rules, err := NewThresholdRulesFromConfig([]types.ThresholdRule{
+		{Tool: "SOLID", Grade: "B"},
+		{Tool: "SOLID", Grade: "C"},
+	})
+	if err != nil {
+		t.Fatalf("NewThresholdRulesFromConfig: %v", err)
+	}
+	if got := NewThresholds(Threshold{Grade: "A"}, rules).Resolve("SOLID", "main.go").Grade; got != "C" {
+		t.Errorf("Resolve grade = %q, expected C", got)
+	}
+}
+
+func TestNewThresholdRulesFromConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    types.ThresholdRule
+		message string
+	}{
+		{"neither tool nor path", types.ThresholdRule{Grade: "B"}, "needs a tool, a path or both"},
+		{"neither grade nor percent", types.ThresholdRule{Tool: "SOLID"}, "sets neither a grade nor a percent"},
+		{"unknown grade", types.ThresholdRule{Tool: "SOLID", Grade: "Q"}, `unknown grade "Q"`},
+		{"negative percent", types.ThresholdRule{Tool: "SOLID", Percent: -5}, "negative percent"},
+		{"negated path", types.ThresholdRule{Path: "!legacy/", Grade: "B"}, "invalid path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewThresholdRulesFromConfig([]types.ThresholdRule{tt.rule})
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("error = %v, expected it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
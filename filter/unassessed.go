@@ -0,0 +1,45 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnassessedPolicy decides how source files without a grade for a tool are treated.
+type UnassessedPolicy string
+
+const (
+	UnassessedPolicyOff  UnassessedPolicy = "off"  // Only graded files count
+	UnassessedPolicyZero UnassessedPolicy = "zero" // Ungraded files count as 0% coverage
+	UnassessedPolicyFail UnassessedPolicy = "fail" // Ungraded files count as 0% and fail the assessment
+)
+
+// ParseUnassessedPolicy validates a policy name.
+func ParseUnassessedPolicy(name string) (UnassessedPolicy, error) {
+	policy := UnassessedPolicy(strings.ToLower(strings.TrimSpace(name)))
+	switch policy {
+	case UnassessedPolicyOff, UnassessedPolicyZero, UnassessedPolicyFail:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown unassessed policy %q: expected off, zero or fail", name)
+}
+
+// FindUnassessed returns an unassessed GradeDetails for every source file and tool that
+// has no entry in details.
+func FindUnassessed(sourceFiles []string, tools []string, details []GradeDetails) []GradeDetails {
+	graded := make(map[string]bool)
+	for _, detail := range details {
+		graded[detail.FileName+"|"+strings.ToLower(detail.Tool)] = true
+	}
+
+	gaps := []GradeDetails{}
+	for _, file := range sourceFiles {
+		for _, tool := range tools {
+			tool = strings.TrimSpace(tool)
+			if !graded[file+"|"+strings.ToLower(tool)] {
+				gaps = append(gaps, NewUnassessedGradeDetails(file, tool))
+			}
+		}
+	}
+	return gaps
+}
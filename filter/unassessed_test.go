@@ -0,0 +1,53 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUnassessedPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected UnassessedPolicy
+		hasError bool
+	}{
+		{"off", UnassessedPolicyOff, false},
+		{" Zero ", UnassessedPolicyZero, false},
+		{"fail", UnassessedPolicyFail, false},
+		{"", "", true},
+		{"skip", "", true},
+	}
+	for _, tt := range tests {
+		policy, err := ParseUnassessedPolicy(tt.name)
+		if policy != tt.expected || (err != nil) != tt.hasError {
+			t.Errorf("ParseUnassessedPolicy(%q) = %q, %v, expected %q and an error: %v", tt.name, policy, err, tt.expected, tt.hasError)
+		}
+	}
+}
+
+func TestFindUnassessed(t *testing.T) {
+	details := []GradeDetails{
+		{FileName: "a.go", Tool: "solid", Grade: "A"},
+		{FileName: "b.go", Tool: "OWASP-TOP-10", Grade: "C"},
+	}
+	gaps := FindUnassessed([]string{"a.go", "b.go", "c.go"}, []string{"SOLID", " OWASP-TOP-10"}, details)
+
+	expected := []GradeDetails{
+		NewUnassessedGradeDetails("a.go", "OWASP-TOP-10"),
+		NewUnassessedGradeDetails("b.go", "SOLID"),
+		NewUnassessedGradeDetails("c.go", "SOLID"),
+		NewUnassessedGradeDetails("c.go", "OWASP-TOP-10"),
+	}
+	if !reflect.DeepEqual(gaps, expected) {
+		t.Errorf("FindUnassessed = %+v, expected %+v", gaps, expected)
+	}
+	for _, gap := range gaps {
+		if !gap.Unassessed || gap.Coverage != 0 {
+			t.Errorf("gap %+v is not an unassessed pair at 0%% coverage", gap)
+		}
+	}
+
+	if gaps := FindUnassessed([]string{"a.go"}, []string{"SOLID"}, details); len(gaps) != 0 {
+		t.Errorf("FindUnassessed reported graded pairs: %+v", gaps)
+	}
+}
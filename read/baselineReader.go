@@ -0,0 +1,47 @@
+package read
+
+import (
+	"codeleft-cli/filter"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// BaselineFileName is the name of the ratchet baseline inside .codeLeft.
+const BaselineFileName = "baseline.json"
+
+// BaselineReader reads the baseline written by the baseline command.
+type BaselineReader interface {
+	ReadBaseline() (*filter.Baseline, error)
+}
+
+// JSONBaselineReader reads baseline.json from Path.
+type JSONBaselineReader struct {
+	Path string
+}
+
+// NewBaselineReader creates a BaselineReader for the baseline.json file at path.
+func NewBaselineReader(path string) BaselineReader {
+	return &JSONBaselineReader{Path: path}
+}
+
+// ReadBaseline decodes the baseline, and fails if it is missing or of an unknown version.
+func (r *JSONBaselineReader) ReadBaseline() (*filter.Baseline, error) {
+	data, err := os.ReadFile(r.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no baseline at %s: run 'codeleft-cli baseline' to create one", r.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading baseline: %w", err)
+	}
+
+	var baseline filter.Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("error decoding baseline %s: %w", r.Path, err)
+	}
+	if baseline.Version != filter.BaselineVersion {
+		return nil, fmt.Errorf("baseline %s has version %d, expected %d", r.Path, baseline.Version, filter.BaselineVersion)
+	}
+	return &baseline, nil
+}
@@ -0,0 +1,61 @@
+package read
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ChangedFilesProvider lists the files changed relative to a git ref.
+type ChangedFilesProvider interface {
+	ChangedFiles(ref string) ([]string, error)
+}
+
+// GitChangedFiles implements ChangedFilesProvider with the git command line.
+type GitChangedFiles struct {
+	RepoRoot string
+}
+
+// NewGitChangedFiles creates a ChangedFilesProvider for the repository rooted at repoRoot.
+func NewGitChangedFiles(repoRoot string) ChangedFilesProvider {
+	return &GitChangedFiles{RepoRoot: repoRoot}
+}
+
+// ChangedFiles returns the repository-relative paths that differ between the merge base of
+// HEAD and ref and the working tree. Deleted files are left out, since they cannot be graded.
+func (g *GitChangedFiles) ChangedFiles(ref string) ([]string, error) {
+	mergeBase, err := g.git("merge-base", "HEAD", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the merge base of HEAD and %s: %w", ref, err)
+	}
+
+	output, err := g.git("diff", "--name-only", "--diff-filter=d", strings.TrimSpace(mergeBase), "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", ref, err)
+	}
+
+	paths := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
+
+// git runs a git subcommand in the repository root and returns its standard output.
+func (g *GitChangedFiles) git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.RepoRoot
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
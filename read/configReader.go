@@ -4,6 +4,7 @@ import (
 	"codeleft-cli/types"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -11,6 +12,7 @@ import (
 // ConfigSource interface for reading configuration.
 type ConfigSource interface {
 	ReadConfig() (*types.Config, error)
+	ReadConfigBytes() ([]byte, error)
 }
 
 // ConfigPathResolver resolves the path to the config file.
@@ -64,6 +66,15 @@ func (cr *ConfigReader) ResolveConfigPath() (string, error) {
 
 // ReadConfig reads the configuration from config.json.
 func (cr *ConfigReader) ReadConfig() (*types.Config, error) {
+	data, err := cr.ReadConfigBytes()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeConfig(data)
+}
+
+// ReadConfigBytes reads config.json without decoding it, e.g. for schema validation.
+func (cr *ConfigReader) ReadConfigBytes() ([]byte, error) {
 	configPath, err := cr.ResolveConfigPath()
 	if err != nil {
 		return nil, err
@@ -73,7 +84,7 @@ func (cr *ConfigReader) ReadConfig() (*types.Config, error) {
 	info, err := cr.FileSystem.Stat(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("config.json does not exist at path: %s", configPath)
+			return nil, fmt.Errorf("config.json does not exist at path: %s (run 'codeleft-cli init' to create it)", configPath)
 		}
 		return nil, fmt.Errorf("error accessing config.json: %w", err)
 	}
@@ -89,12 +100,18 @@ func (cr *ConfigReader) ReadConfig() (*types.Config, error) {
 	}
 	defer file.Close()
 
-	// Decode the JSON into a Config struct
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config.json: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeConfig decodes the contents of config.json into a Config struct.
+func DecodeConfig(data []byte) (*types.Config, error) {
 	var config types.Config
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&config); err != nil {
+	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to decode config.json: %w", err)
 	}
-
 	return &config, nil
-}
\ No newline at end of file
+}
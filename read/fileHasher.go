@@ -0,0 +1,36 @@
+package read
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SHA256FileHasher implements filter.FileHasher with the SHA-256 digest that history
+// records store in their hash field.
+type SHA256FileHasher struct {
+	Root string
+}
+
+// NewSHA256FileHasher creates a SHA256FileHasher for the repository rooted at root.
+func NewSHA256FileHasher(root string) *SHA256FileHasher {
+	return &SHA256FileHasher{Root: root}
+}
+
+// Hash returns the hex-encoded SHA-256 of the file's content.
+func (h *SHA256FileHasher) Hash(relativePath string) (string, error) {
+	file, err := os.Open(filepath.Join(h.Root, filepath.FromSlash(relativePath)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", relativePath, err)
+	}
+	defer file.Close()
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", relativePath, err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
@@ -0,0 +1,29 @@
+package read
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSHA256FileHasherHash(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "cli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cli", "a.go"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hasher := NewSHA256FileHasher(root)
+
+	hash, err := hasher.Hash("cli/a.go")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if expected := "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"; hash != expected {
+		t.Errorf("Hash = %s, expected %s", hash, expected)
+	}
+	if _, err := hasher.Hash("cli/gone.go"); err == nil {
+		t.Errorf("hashing a missing file succeeded")
+	}
+}
@@ -14,6 +14,17 @@ import (
 // CodeLeftReader interface remains the same
 type CodeLeftReader interface {
 	ReadHistory() (filter.Histories, error)
+	ReadRecords() ([]HistoryRecord, error)
+	HistoryPath() string
+}
+
+// HistoryRecord is a single line of history.ndjson.
+// Raw keeps the line verbatim so that rewriting the file never drops fields
+// which filter.History does not model (codeDiff, frontMatter, ...).
+type HistoryRecord struct {
+	Line    int
+	Raw     []byte
+	History filter.History
 }
 
 // HistoryReader is responsible for reading the history.ndjson file.
@@ -45,15 +56,35 @@ func NewHistoryReader() (CodeLeftReader, error) {
 	return hr, nil
 }
 
+// HistoryPath returns the location of history.ndjson inside the discovered .codeleft directory.
+func (hr *HistoryReader) HistoryPath() string {
+	return filepath.Join(hr.CodeleftPath, "history.ndjson")
+}
+
 // ReadHistory reads the history.ndjson file from the discovered .codeleft directory.
 // Returns an error if the history.ndjson file is not found or cannot be read.
 func (hr *HistoryReader) ReadHistory() (filter.Histories, error) {
+	records, err := hr.ReadRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make(filter.Histories, 0, len(records))
+	for _, record := range records {
+		histories = append(histories, record.History)
+	}
+	return histories, nil
+}
+
+// ReadRecords reads every non-empty line of history.ndjson, keeping the raw line
+// and its line number next to the decoded History.
+func (hr *HistoryReader) ReadRecords() ([]HistoryRecord, error) {
 	// If .codeleft was not found, return an error
 	if hr.CodeleftPath == "" {
 		return nil, fmt.Errorf(".codeLeft folder not found in the repository root: %s", hr.RepoRoot)
 	}
 
-	historyPath := filepath.Join(hr.CodeleftPath, "history.ndjson")
+	historyPath := hr.HistoryPath()
 
 	// Check if history.ndjson exists
 	info, err := os.Stat(historyPath)
@@ -75,66 +106,48 @@ func (hr *HistoryReader) ReadHistory() (filter.Histories, error) {
 	}
 	defer file.Close()
 
-	// Decode the NDJSON into a slice of History
-	var history filter.Histories
-	histories := filter.Histories{}
-	
+	records := []HistoryRecord{}
+
 	// Use a more robust line-by-line reader that can handle very large lines
 	reader := bufio.NewReader(file)
 	lineNumber := 0
-	
+
 	for {
 		lineNumber++
-		
+
 		// Read a complete line, handling very large lines gracefully
 		var lineBuffer bytes.Buffer
+		eof := false
 		for {
 			chunk, isPrefix, err := reader.ReadLine()
 			if err != nil {
 				if err == io.EOF {
-					// Process any remaining content in the buffer
-					if lineBuffer.Len() > 0 {
-						line := bytes.TrimSpace(lineBuffer.Bytes())
-						if len(line) > 0 {
-							var item filter.History
-							if err := json.Unmarshal(line, &item); err != nil {
-								return nil, fmt.Errorf("failed to decode history.ndjson at line %d: %w", lineNumber, err)
-							}
-							histories = append(histories, item)
-						}
-					}
-					goto done
+					eof = true
+					break
 				}
 				return nil, fmt.Errorf("error reading history.ndjson at line %d: %w", lineNumber, err)
 			}
-			
+
 			lineBuffer.Write(chunk)
-			
+
 			// If isPrefix is false, we've read the complete line
 			if !isPrefix {
 				break
 			}
 		}
-		
-		// Process the complete line
+
+		// Process the complete line, skipping empty ones
 		line := bytes.TrimSpace(lineBuffer.Bytes())
-		
-		// Skip empty lines
-		if len(line) == 0 {
-			continue
+		if len(line) > 0 {
+			var item filter.History
+			if err := json.Unmarshal(line, &item); err != nil {
+				return nil, fmt.Errorf("failed to decode history.ndjson at line %d: %w", lineNumber, err)
+			}
+			records = append(records, HistoryRecord{Line: lineNumber, Raw: line, History: item})
 		}
-		
-		// Parse the JSON line
-		var item filter.History
-		if err := json.Unmarshal(line, &item); err != nil {
-			return nil, fmt.Errorf("failed to decode history.ndjson at line %d: %w", lineNumber, err)
+
+		if eof {
+			return records, nil
 		}
-		
-		histories = append(histories, item)
 	}
-	
-done:
-	history = histories
-
-	return history, nil
 }
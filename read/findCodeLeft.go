@@ -27,7 +27,7 @@ func findCodeleftRecursive(root string) (string, error) {
 	}
 
 	if codeleftPath == "" {
-		return "", fmt.Errorf(".codeLeft directory does not exist anywhere under: %s", root)
+		return "", fmt.Errorf(".codeLeft directory does not exist anywhere under: %s (run 'codeleft-cli init' to create it)", root)
 	}
 
 	return codeleftPath, nil
@@ -0,0 +1,133 @@
+package read
+
+import (
+	"bufio"
+	"codeleft-cli/filter"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// IgnoreFileReader loads gitignore-style rule files from the repository.
+type IgnoreFileReader interface {
+	ReadGitignorePatterns() ([]filter.IgnorePattern, error)
+	ReadCodeleftignorePatterns() ([]filter.IgnorePattern, error)
+}
+
+// RepoIgnoreFileReader reads .gitignore files anywhere in the repository and the
+// .codeleftignore file inside .codeLeft.
+type RepoIgnoreFileReader struct {
+	RepoRoot     string
+	CodeleftPath string
+}
+
+// NewIgnoreFileReader creates an IgnoreFileReader for the repository containing the current working directory.
+func NewIgnoreFileReader() (IgnoreFileReader, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	repoRoot := FindRepoRoot(cwd)
+
+	codeleftPath, err := findCodeleftRecursive(cwd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RepoIgnoreFileReader{
+		RepoRoot:     repoRoot,
+		CodeleftPath: codeleftPath,
+	}, nil
+}
+
+// ReadGitignorePatterns reads every .gitignore below the repository root, parents before
+// children, so that patterns from deeper files take precedence. Directories ignored by the
+// patterns read so far are not searched.
+func (r *RepoIgnoreFileReader) ReadGitignorePatterns() ([]filter.IgnorePattern, error) {
+	patterns := []filter.IgnorePattern{}
+
+	err := filepath.WalkDir(r.RepoRoot, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		relative, err := filepath.Rel(r.RepoRoot, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		if relative == "." {
+			relative = ""
+		} else if directoryIgnored(patterns, relative) {
+			return filepath.SkipDir
+		}
+
+		filePatterns, err := readPatternFile(filepath.Join(path, ".gitignore"), relative, joinOrigin(relative, ".gitignore"))
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, filePatterns...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .gitignore files: %w", err)
+	}
+	return patterns, nil
+}
+
+// ReadCodeleftignorePatterns reads .codeLeft/.codeleftignore. Its patterns are relative to
+// the repository root. A missing file yields no patterns.
+func (r *RepoIgnoreFileReader) ReadCodeleftignorePatterns() ([]filter.IgnorePattern, error) {
+	return readPatternFile(filepath.Join(r.CodeleftPath, ".codeleftignore"), "", ".codeLeft/.codeleftignore")
+}
+
+// readPatternFile parses a gitignore-style file. A missing file is not an error.
+func readPatternFile(path string, base string, origin string) ([]filter.IgnorePattern, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	patterns := []filter.IgnorePattern{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if pattern, ok := filter.ParseIgnorePattern(scanner.Text(), base); ok {
+			pattern.Origin = fmt.Sprintf("%s:%d", origin, lineNumber)
+			patterns = append(patterns, pattern)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+// directoryIgnored applies last-match-wins to decide whether a directory is ignored.
+func directoryIgnored(patterns []filter.IgnorePattern, dir string) bool {
+	for i := len(patterns) - 1; i >= 0; i-- {
+		if patterns[i].MatchDirectory(dir) {
+			return !patterns[i].Negate
+		}
+	}
+	return false
+}
+
+func joinOrigin(dir string, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
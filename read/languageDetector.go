@@ -0,0 +1,89 @@
+package read
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LanguageDetector detects the programming languages used in a project.
+type LanguageDetector interface {
+	DetectLanguages(root string) ([]string, error)
+}
+
+// ExtensionLanguageDetector detects languages by counting source file extensions.
+type ExtensionLanguageDetector struct{}
+
+func NewExtensionLanguageDetector() LanguageDetector {
+	return &ExtensionLanguageDetector{}
+}
+
+// languageExtensions maps lower-case file extensions to language names.
+var languageExtensions = map[string]string{
+	".c":     "C",
+	".h":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cxx":   "C++",
+	".hh":    "C++",
+	".hpp":   "C++",
+	".cs":    "C#",
+	".go":    "Go",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".mjs":   "JavaScript",
+	".kt":    "Kotlin",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".swift": "Swift",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+}
+
+// skippedDirectories are never descended into; hidden directories are skipped as well.
+var skippedDirectories = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// DetectLanguages walks root and returns the languages found, most files first.
+func (d *ExtensionLanguageDetector) DetectLanguages(root string) ([]string, error) {
+	counts := make(map[string]int)
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirectories[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if language, ok := languageExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+			counts[language]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]string, 0, len(counts))
+	for language := range counts {
+		languages = append(languages, language)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if counts[languages[i]] != counts[languages[j]] {
+			return counts[languages[i]] > counts[languages[j]]
+		}
+		return languages[i] < languages[j]
+	})
+	return languages, nil
+}
@@ -0,0 +1,37 @@
+package read
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtensionLanguageDetectorDetectLanguages(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		"main.go", "cli/cli.go", "cli/Options.GO",
+		"web/app.ts", "web/view.tsx",
+		"native/lib.c",
+		"README.md",
+		"node_modules/dep/index.js", "vendor/dep/dep.go", ".cache/gen.py",
+	}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	languages, err := NewExtensionLanguageDetector().DetectLanguages(root)
+	if err != nil {
+		t.Fatalf("DetectLanguages: %v", err)
+	}
+	// Most files first, ties by name; dependencies and hidden directories do not count.
+	if expected := []string{"Go", "TypeScript", "C"}; !reflect.DeepEqual(languages, expected) {
+		t.Errorf("DetectLanguages = %v, expected %v", languages, expected)
+	}
+}
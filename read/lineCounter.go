@@ -0,0 +1,46 @@
+package read
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NonBlankLineCounter implements filter.LineCounter by counting the lines of a file that
+// are not blank.
+type NonBlankLineCounter struct {
+	Root string
+}
+
+// NewNonBlankLineCounter creates a NonBlankLineCounter for the repository rooted at root.
+func NewNonBlankLineCounter(root string) *NonBlankLineCounter {
+	return &NonBlankLineCounter{Root: root}
+}
+
+// CountLines returns the number of non-blank lines in the file.
+func (c *NonBlankLineCounter) CountLines(relativePath string) (int, error) {
+	file, err := os.Open(filepath.Join(c.Root, filepath.FromSlash(relativePath)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", relativePath, err)
+	}
+	defer file.Close()
+
+	lines := 0
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.TrimSpace(line) != "" {
+			lines++
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return lines, nil
+			}
+			return 0, fmt.Errorf("failed to read %s: %w", relativePath, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package read
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// FindRepoRoot walks up from start to the nearest directory containing .git.
+// If there is none, start itself is treated as the repository root.
+func FindRepoRoot(start string) string {
+	dir := start
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start
+		}
+		dir = parent
+	}
+}
+
+// OSFileChecker implements filter.FileExistenceChecker against the working tree.
+type OSFileChecker struct {
+	Root string
+}
+
+// NewOSFileChecker creates an OSFileChecker for the repository rooted at root.
+func NewOSFileChecker(root string) *OSFileChecker {
+	return &OSFileChecker{Root: root}
+}
+
+// Exists reports whether the repository-relative path is a regular file.
+func (c *OSFileChecker) Exists(relativePath string) bool {
+	info, err := os.Stat(filepath.Join(c.Root, filepath.FromSlash(relativePath)))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// DirectoryExists reports whether the repository-relative path is a directory.
+func (c *OSFileChecker) DirectoryExists(relativePath string) bool {
+	info, err := os.Stat(filepath.Join(c.Root, filepath.FromSlash(relativePath)))
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,88 @@
+package read
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SourceExtensions is a set of lower-case file extensions, such as ".go", that mark a file
+// as source code.
+type SourceExtensions map[string]bool
+
+// NewSourceExtensions builds the set from configured extensions. With none configured, the
+// extensions of every language the language detector knows are used.
+func NewSourceExtensions(extensions []string) SourceExtensions {
+	set := make(SourceExtensions)
+	for _, extension := range extensions {
+		extension = strings.ToLower(strings.TrimSpace(extension))
+		if extension == "" {
+			continue
+		}
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+		set[extension] = true
+	}
+	if len(set) == 0 {
+		for extension := range languageExtensions {
+			set[extension] = true
+		}
+	}
+	return set
+}
+
+// Matches reports whether the path has one of the extensions.
+func (s SourceExtensions) Matches(path string) bool {
+	return s[strings.ToLower(filepath.Ext(path))]
+}
+
+// SourceFileLister lists the source files in a repository.
+type SourceFileLister interface {
+	ListSourceFiles() ([]string, error)
+}
+
+// RepoSourceFileLister walks the working tree for files with a source extension.
+type RepoSourceFileLister struct {
+	Root       string
+	Extensions SourceExtensions
+}
+
+// NewSourceFileLister creates a SourceFileLister for the repository rooted at root.
+func NewSourceFileLister(root string, extensions SourceExtensions) SourceFileLister {
+	return &RepoSourceFileLister{Root: root, Extensions: extensions}
+}
+
+// ListSourceFiles returns repository-relative, slash-separated paths in sorted order.
+// Hidden directories, node_modules and vendor are not searched.
+func (l *RepoSourceFileLister) ListSourceFiles() ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(l.Root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != l.Root && (strings.HasPrefix(name, ".") || skippedDirectories[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !l.Extensions.Matches(path) {
+			return nil
+		}
+
+		relative, err := filepath.Rel(l.Root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relative))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
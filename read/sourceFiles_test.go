@@ -0,0 +1,56 @@
+package read
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewSourceExtensions(t *testing.T) {
+	extensions := NewSourceExtensions([]string{".GO", "ts", " ", " .py "})
+	expected := SourceExtensions{".go": true, ".ts": true, ".py": true}
+	if !reflect.DeepEqual(extensions, expected) {
+		t.Errorf("NewSourceExtensions = %v, expected %v", extensions, expected)
+	}
+	if !extensions.Matches("cli/Main.Go") || extensions.Matches("README.md") {
+		t.Errorf("Matches does not compare extensions case-insensitively")
+	}
+
+	defaults := NewSourceExtensions(nil)
+	for extension := range languageExtensions {
+		if !defaults[extension] {
+			t.Errorf("default extensions are missing %s", extension)
+		}
+	}
+}
+
+func TestRepoSourceFileListerListSourceFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"main.go",
+		"cli/b.go",
+		"cli/a.go",
+		"cli/notes.md",
+		".codeLeft/tool.go",
+		"vendor/lib/lib.go",
+		"web/node_modules/pkg/index.go",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := NewSourceFileLister(root, NewSourceExtensions([]string{".go"})).ListSourceFiles()
+	if err != nil {
+		t.Fatalf("ListSourceFiles: %v", err)
+	}
+	expected := []string{"cli/a.go", "cli/b.go", "main.go"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("ListSourceFiles = %v, expected %v", files, expected)
+	}
+}
@@ -0,0 +1,184 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultBadgeDir is where "report badges" writes by default.
+const DefaultBadgeDir = "codeleft-badges"
+
+// CoverageBadgeFile is the name of the overall coverage badge.
+const CoverageBadgeFile = "coverage.svg"
+
+// Badge is a two-part badge such as "CodeLeft coverage | 87%".
+type Badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+// NamedBadge is a badge together with the file name it is written to.
+type NamedBadge struct {
+	FileName string
+	Badge    Badge
+}
+
+// BadgeReport writes SVG badges for the overall coverage and for each tool.
+type BadgeReport struct {
+	OutputDir string
+}
+
+func NewBadgeReport(outputDir string) IReport {
+	return &BadgeReport{OutputDir: outputDir}
+}
+
+func (b *BadgeReport) GenerateReport(gradeDetails []filter.GradeDetails, threshold string, weigher filter.CoverageWeigher) error {
+	return GenerateReport(gradeDetails, b.OutputDir, threshold, weigher, NewBadgeWriter())
+}
+
+// BadgeWriter implements ReportWriter by writing one SVG file per badge into the output
+// directory.
+type BadgeWriter struct{}
+
+func NewBadgeWriter() *BadgeWriter {
+	return &BadgeWriter{}
+}
+
+func (w *BadgeWriter) Write(data ReportViewData, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory '%s': %w", outputDir, err)
+	}
+	for _, named := range BuildBadges(data) {
+		path := filepath.Join(outputDir, named.FileName)
+		if err := os.WriteFile(path, []byte(RenderBadgeSVG(named.Badge)), 0644); err != nil {
+			return fmt.Errorf("failed to write badge '%s': %w", path, err)
+		}
+	}
+	return nil
+}
+
+// BuildBadges returns the overall coverage badge, "coverage.svg", followed by a badge per
+// tool, e.g. "solid.svg" reading "SOLID | A-". A tool badge shows the tool's average grade
+// and is coloured by its average coverage, like the HTML report.
+func BuildBadges(data ReportViewData) []NamedBadge {
+	badges := []NamedBadge{{
+		FileName: CoverageBadgeFile,
+		Badge: Badge{
+			Label:   "CodeLeft coverage",
+			Message: fmt.Sprintf("%.0f%%", data.TotalAverage),
+			Color:   coverageColor(data.TotalAverage),
+		},
+	}}
+
+	grades := averageToolGrades(data.RootNodes)
+	tools := append([]string{}, data.AllTools...)
+	sort.Strings(tools)
+	for _, tool := range tools {
+		grade, ok := grades[tool]
+		if !ok {
+			grade = "unassessed"
+		}
+		badges = append(badges, NamedBadge{
+			FileName: badgeFileName(tool),
+			Badge:    Badge{Label: tool, Message: grade, Color: coverageColor(data.OverallAverages[tool])},
+		})
+	}
+	return badges
+}
+
+// averageToolGrades rounds each tool's mean grade index over the graded files to a grade
+// on the built-in scale.
+func averageToolGrades(nodes []*ReportNode) map[string]string {
+	sums := make(map[string]int)
+	counts := make(map[string]int)
+	walkReportNodes(nodes, func(node *ReportNode) {
+		for _, detail := range node.Details {
+			if detail.Unassessed || detail.Grade == "" {
+				continue
+			}
+			sums[detail.Tool] += detail.Score
+			counts[detail.Tool]++
+		}
+	})
+
+	grades := make(map[string]string)
+	for tool, count := range counts {
+		mean := float64(sums[tool]) / float64(count)
+		grades[tool] = filter.GradeAtIndex(int(math.Round(mean)))
+	}
+	return grades
+}
+
+// badgeFileName turns a tool name into a file name, e.g. "OWASP-TOP-10" into "owasp-top-10.svg".
+func badgeFileName(tool string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(tool) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "-")
+	if name == "" {
+		name = "tool"
+	}
+	return name + ".svg"
+}
+
+// RenderBadgeSVG renders a self-contained badge in the common flat style.
+func RenderBadgeSVG(badge Badge) string {
+	labelWidth := textWidth(badge.Label) + 10
+	messageWidth := textWidth(badge.Message) + 10
+	width := labelWidth + messageWidth
+	label := html.EscapeString(badge.Label)
+	message := html.EscapeString(badge.Message)
+	color := html.EscapeString(badge.Color)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", width, label, message)
+	fmt.Fprintf(&b, "  <title>%s: %s</title>\n", label, message)
+	b.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&b, `  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	fmt.Fprintf(&b, `  <g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+"\n",
+		labelWidth, labelWidth, messageWidth, color, width)
+	b.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	for _, text := range []struct {
+		x     float64
+		value string
+	}{{float64(labelWidth) / 2, label}, {float64(labelWidth) + float64(messageWidth)/2, message}} {
+		fmt.Fprintf(&b, `    <text x="%.1f" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%.1f" y="14">%s</text>`+"\n", text.x, text.value, text.x, text.value)
+	}
+	b.WriteString("  </g>\n</svg>\n")
+	return b.String()
+}
+
+// textWidth estimates the width in pixels of text in 11px Verdana.
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("il.,:;|!' ", r):
+			width += 3.9
+		case strings.ContainsRune("fjrt()-", r):
+			width += 4.9
+		case strings.ContainsRune("MWmw%", r):
+			width += 10.5
+		case r >= 'A' && r <= 'Z':
+			width += 7.6
+		default:
+			width += 6.9
+		}
+	}
+	return int(math.Ceil(width))
+}
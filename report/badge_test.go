@@ -0,0 +1,97 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuildBadges(t *testing.T) {
+	data := markdownViewData(t, []filter.GradeDetails{
+		{FileName: "cli/a.go", Tool: "SOLID", Grade: "A", Score: 11, Coverage: 110},
+		{FileName: "cli/b.go", Tool: "SOLID", Grade: "C", Score: 5, Coverage: 50},
+		{FileName: "cli/a.go", Tool: "OWASP-TOP-10", Grade: "B-", Score: 7, Coverage: 90},
+		{FileName: "cli/b.go", Tool: "OWASP-TOP-10", Grade: "B", Score: 8, Coverage: 100},
+		{FileName: "cli/a.go", Tool: "Clean Code", Unassessed: true},
+	})
+
+	expected := []NamedBadge{
+		{FileName: "coverage.svg", Badge: Badge{Label: "CodeLeft coverage", Message: "70%", Color: coverageColor(70)}},
+		{FileName: "clean-code.svg", Badge: Badge{Label: "Clean Code", Message: "unassessed", Color: coverageColor(0)}},
+		{FileName: "owasp-top-10.svg", Badge: Badge{Label: "OWASP-TOP-10", Message: "B", Color: coverageColor(95)}}, // 7.5 rounds up
+		{FileName: "solid.svg", Badge: Badge{Label: "SOLID", Message: "B", Color: coverageColor(80)}},
+	}
+	if badges := BuildBadges(data); !reflect.DeepEqual(badges, expected) {
+		t.Errorf("BuildBadges = %+v, expected %+v", badges, expected)
+	}
+}
+
+func TestBadgeFileName(t *testing.T) {
+	tests := []struct {
+		tool     string
+		expected string
+	}{
+		{"SOLID", "solid.svg"},
+		{"OWASP-TOP-10", "owasp-top-10.svg"},
+		{"Clean Code", "clean-code.svg"},
+		{" C#/.NET rules ", "c-net-rules.svg"},
+		{"++", "tool.svg"},
+	}
+	for _, tt := range tests {
+		if name := badgeFileName(tt.tool); name != tt.expected {
+			t.Errorf("badgeFileName(%q) = %s, expected %s", tt.tool, name, tt.expected)
+		}
+	}
+}
+
+func TestRenderBadgeSVG(t *testing.T) {
+	svg := RenderBadgeSVG(Badge{Label: "R&D <tools>", Message: "A-", Color: "#76C474"})
+	for _, text := range []string{
+		`aria-label="R&amp;D &lt;tools&gt;: A-"`,
+		"<title>R&amp;D &lt;tools&gt;: A-</title>",
+		`fill="#76C474"`,
+	} {
+		if !strings.Contains(svg, text) {
+			t.Errorf("badge does not contain %q:\n%s", text, svg)
+		}
+	}
+	if strings.Contains(svg, "<tools>") {
+		t.Errorf("badge text was not escaped:\n%s", svg)
+	}
+	if longer := RenderBadgeSVG(Badge{Label: "R&D <tools>", Message: "unassessed"}); badgeWidth(t, longer) <= badgeWidth(t, svg) {
+		t.Errorf("a longer message did not widen the badge")
+	}
+}
+
+// badgeWidth reads the width attribute of a rendered badge.
+func badgeWidth(t *testing.T, svg string) int {
+	t.Helper()
+	_, rest, _ := strings.Cut(svg, `width="`)
+	attribute, _, _ := strings.Cut(rest, `"`)
+	width, err := strconv.Atoi(attribute)
+	if err != nil {
+		t.Fatalf("badge has no width:\n%s", svg)
+	}
+	return width
+}
+
+func TestBadgeWriterWrite(t *testing.T) {
+	data := markdownViewData(t, []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Grade: "A", Score: 11, Coverage: 110}})
+	dir := filepath.Join(t.TempDir(), "badges")
+	if err := NewBadgeWriter().Write(data, dir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	for _, named := range BuildBadges(data) {
+		written, err := os.ReadFile(filepath.Join(dir, named.FileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(written) != RenderBadgeSVG(named.Badge) {
+			t.Errorf("%s holds %q", named.FileName, written)
+		}
+	}
+}
@@ -18,7 +18,8 @@ type PathSplitter interface {
 	Split(path string) []string
 }
 
-// SeparatorPathSplitter splits paths by the OS-specific separator.
+// SeparatorPathSplitter splits slash-separated paths, the form history paths are
+// canonicalised to before they reach the report.
 type SeparatorPathSplitter struct{}
 
 func NewSeparatorPathSplitter() PathSplitter {
@@ -26,7 +27,7 @@ func NewSeparatorPathSplitter() PathSplitter {
 }
 
 func (s *SeparatorPathSplitter) Split(path string) []string {
-	return strings.Split(path, string(filepath.Separator))
+	return strings.Split(filepath.ToSlash(path), "/")
 }
 
 // NodeCreator interface for creating ReportNode instances.
@@ -44,19 +45,49 @@ func NewDefaultNodeCreator() NodeCreator {
 
 func (c *DefaultNodeCreator) CreateFileNode(name string, path string, details []filter.GradeDetails) *ReportNode {
 	return &ReportNode{
-		Name:    name,
-		Path:    path,
-		IsDir:   false,
-		Details: details,
+		Name:            name,
+		Path:            path,
+		IsDir:           false,
+		Details:         details,
+		ToolCoverages:   make(map[string]float64),
+		ToolCoverageOk:  make(map[string]bool),
+		StaleTools:      staleTools(details),
+		UnassessedTools: unassessedTools(details),
 	}
 }
 
+// unassessedTools lists, in order, the tools that never graded the file.
+func unassessedTools(details []filter.GradeDetails) []string {
+	var tools []string
+	for _, detail := range details {
+		if detail.Unassessed {
+			tools = append(tools, detail.Tool)
+		}
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// staleTools lists, in order, the tools whose grade no longer matches the file.
+func staleTools(details []filter.GradeDetails) []string {
+	var tools []string
+	for _, detail := range details {
+		if detail.Stale {
+			tools = append(tools, detail.Tool)
+		}
+	}
+	sort.Strings(tools)
+	return tools
+}
+
 func (c *DefaultNodeCreator) CreateDirectoryNode(name string, path string) *ReportNode {
 	return &ReportNode{
-		Name:     name,
-		Path:     path,
-		IsDir:    true,
-		Children: []*ReportNode{},
+		Name:           name,
+		Path:           path,
+		IsDir:          true,
+		Children:       []*ReportNode{},
+		ToolCoverages:  make(map[string]float64),
+		ToolCoverageOk: make(map[string]bool),
 	}
 }
 
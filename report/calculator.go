@@ -2,34 +2,30 @@ package report
 
 import (
 	"sort"
+	"codeleft-cli/assessment"
 	"codeleft-cli/filter"
 )
 
 // CoverageCalculator encapsulates the logic for calculating various coverage metrics.
 // SRP: Focused on coverage calculation logic.
+// Each grade's coverage is the one its GradeDetails carries, as in the assessments, and
+// averages are weighted by the same filter.CoverageWeigher.
 type CoverageCalculator struct {
-	ThresholdGrade string
+	Weigher filter.CoverageWeigher
 }
 
-func NewCoverageCalculator(thresholdGrade string) *CoverageCalculator {
-	return &CoverageCalculator{ThresholdGrade: thresholdGrade}
+func NewCoverageCalculator(weigher filter.CoverageWeigher) *CoverageCalculator {
+	return &CoverageCalculator{Weigher: weigher}
 }
 
 // GlobalStats holds aggregated statistics across the entire report.
 type GlobalStats struct {
-	ToolSet              map[string]struct{}
-	ToolCoverageSums     map[string]float64
-	ToolFileCounts       map[string]int
-	TotalCoverageSum     float64 // Sum of *average* coverage for each unique file
-	UniqueFilesProcessed map[string]struct{}
+	ToolSet map[string]struct{}
 }
 
 func NewGlobalStats() *GlobalStats {
 	return &GlobalStats{
-		ToolSet:              make(map[string]struct{}),
-		ToolCoverageSums:     make(map[string]float64),
-		ToolFileCounts:       make(map[string]int),
-		UniqueFilesProcessed: make(map[string]struct{}),
+		ToolSet: make(map[string]struct{}),
 	}
 }
 
@@ -50,6 +46,7 @@ func (cc *CoverageCalculator) CalculateNodeCoverages(node *ReportNode, stats *Gl
 // calculateFileNodeCoverage calculates coverage for a single file node.
 func (cc *CoverageCalculator) calculateFileNodeCoverage(node *ReportNode, stats *GlobalStats) {
 	var fileOverallCoverageSum float64
+	var fileToolWeight float64
 	var fileToolCount int
 	processedToolsThisFile := make(map[string]struct{}) // Ensure each tool contributes once per file
 
@@ -57,9 +54,11 @@ func (cc *CoverageCalculator) calculateFileNodeCoverage(node *ReportNode, stats
 	if (len(node.Details) == 0) && node.ToolCoverages == nil {
 		return
 	}
+	node.weight = cc.Weigher.FileWeight(node.Path)
+	node.toolWeights = make(map[string]float64)
 
 	for _, detail := range node.Details {
-		if detail.Tool == "" || detail.Grade == "" {
+		if detail.Tool == "" || (detail.Grade == "" && !detail.Unassessed) {
 			continue // Skip if tool or grade is missing
 		}
 		tool := detail.Tool
@@ -67,29 +66,23 @@ func (cc *CoverageCalculator) calculateFileNodeCoverage(node *ReportNode, stats
 			continue // Only count first entry for a tool for this specific file node calculation
 		}
 
-		coverage := filter.CalculateCoverageScore(detail.Grade, cc.ThresholdGrade)
+		coverage := float64(detail.Coverage) // Unassessed files count as 0% coverage
 		node.ToolCoverages[tool] = coverage
 		node.ToolCoverageOk[tool] = true
+		node.toolWeights[tool] = node.weight
 		stats.ToolSet[tool] = struct{}{} // Add tool to global set
 
-		// Add to global sums/counts *only once* per file/tool combo
-		stats.ToolCoverageSums[tool] += coverage
-		stats.ToolFileCounts[tool]++
-
-		fileOverallCoverageSum += coverage
+		toolWeight := cc.Weigher.ToolWeight(tool)
+		fileOverallCoverageSum += toolWeight * coverage
+		fileToolWeight += toolWeight
 		fileToolCount++
 		processedToolsThisFile[tool] = struct{}{}
 	}
 
 	// Calculate the file's overall average coverage
 	if fileToolCount > 0 {
-		node.Coverage = fileOverallCoverageSum / float64(fileToolCount)
+		node.Coverage = fileOverallCoverageSum / fileToolWeight
 		node.CoverageOk = true
-		// Add to global *total* average calculation *if* not already processed
-		if _, processed := stats.UniqueFilesProcessed[node.Path]; !processed {
-             stats.TotalCoverageSum += node.Coverage // Add file's average coverage
-             stats.UniqueFilesProcessed[node.Path] = struct{}{}
-        }
 	} else {
 		node.CoverageOk = false // No tools/grades found for this file
 	}
@@ -103,22 +96,27 @@ func (cc *CoverageCalculator) calculateDirectoryNodeCoverage(node *ReportNode, s
 	}
 
 	// Now calculate this directory's averages based on its children
+	// Children are weighted by the filter.CoverageWeigher
 	var dirOverallCoverageSum float64
+	var dirOverallWeight float64
 	dirNodesWithOverallCoverage := 0
 	dirToolCoverageSums := make(map[string]float64)
+	dirToolWeights := make(map[string]float64)
 	dirToolCoverageCounts := make(map[string]int)
 
 	for _, child := range node.Children {
 		// Aggregate overall coverage for the directory average
 		if child.CoverageOk {
-			dirOverallCoverageSum += child.Coverage
+			dirOverallCoverageSum += child.weight * child.Coverage
+			dirOverallWeight += child.weight
 			dirNodesWithOverallCoverage++
 		}
 
 		// Aggregate per-tool coverage for the directory average
 		for tool, coverage := range child.ToolCoverages {
 			if child.ToolCoverageOk[tool] { // Check if the child had valid coverage for this tool
-				dirToolCoverageSums[tool] += coverage
+				dirToolCoverageSums[tool] += child.toolWeights[tool] * coverage
+				dirToolWeights[tool] += child.toolWeights[tool]
 				dirToolCoverageCounts[tool]++
 				// toolSet is already populated by file processing or deeper recursion
 			}
@@ -127,17 +125,20 @@ func (cc *CoverageCalculator) calculateDirectoryNodeCoverage(node *ReportNode, s
 
 	// Calculate and set the directory's overall average coverage
 	if dirNodesWithOverallCoverage > 0 {
-		node.Coverage = dirOverallCoverageSum / float64(dirNodesWithOverallCoverage)
+		node.Coverage = dirOverallCoverageSum / dirOverallWeight
+		node.weight = cc.Weigher.DirectoryWeight(dirOverallWeight)
 		node.CoverageOk = true
 	} else {
 		node.CoverageOk = false // No children with valid coverage
 	}
 
 	// Calculate and set the directory's per-tool average coverage
+	node.toolWeights = make(map[string]float64)
 	for tool, sum := range dirToolCoverageSums {
 		count := dirToolCoverageCounts[tool]
 		if count > 0 {
-			node.ToolCoverages[tool] = sum / float64(count)
+			node.ToolCoverages[tool] = sum / dirToolWeights[tool]
+			node.toolWeights[tool] = cc.Weigher.DirectoryWeight(dirToolWeights[tool])
 			node.ToolCoverageOk[tool] = true
 		}
 		// No need for else, ToolCoverageOk map default is false
@@ -145,32 +146,20 @@ func (cc *CoverageCalculator) calculateDirectoryNodeCoverage(node *ReportNode, s
 }
 
 
-// CalculateOverallAverages computes the final report-wide averages from global stats.
-// SRP: Focused on calculating final aggregate averages.
-func (cc *CoverageCalculator) CalculateOverallAverages(stats *GlobalStats) (overallAvg map[string]float64, totalAvg float64, allTools []string) {
-	overallAvg = make(map[string]float64)
+// CalculateOverallAverages computes the final report-wide averages of the details.
+// They come from assessment.NewAverages, so they match the averages the coverage gates
+// pass or fail on: every file/tool pair counts by its weight.
+func (cc *CoverageCalculator) CalculateOverallAverages(stats *GlobalStats, details []filter.GradeDetails) (overallAvg map[string]float64, totalAvg float64, allTools []string) {
 	allTools = make([]string, 0, len(stats.ToolSet))
 	for tool := range stats.ToolSet {
 		allTools = append(allTools, tool)
 	}
 	sort.Strings(allTools)
 
-	// Calculate average per tool using globally collected sums/counts
+	averages := assessment.NewAverages(details, cc.Weigher)
+	overallAvg = make(map[string]float64)
 	for _, tool := range allTools {
-		sum := stats.ToolCoverageSums[tool]
-		count := stats.ToolFileCounts[tool]
-		if count > 0 {
-			overallAvg[tool] = sum / float64(count)
-		} else {
-			overallAvg[tool] = 0 // Or potentially math.NaN()
-		}
+		overallAvg[tool] = averages.Tools[tool]
 	}
-
-	// Calculate final total average across all unique files with coverage
-    totalUniqueFilesWithCoverage := len(stats.UniqueFilesProcessed)
-	if totalUniqueFilesWithCoverage > 0 {
-		totalAvg = stats.TotalCoverageSum / float64(totalUniqueFilesWithCoverage)
-	}
-
-	return overallAvg, totalAvg, allTools
-}
\ No newline at end of file
+	return overallAvg, averages.Overall, allTools
+}
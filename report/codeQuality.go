@@ -0,0 +1,198 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultCodeQualityReportPath is where "report codequality" writes by default, the name
+// GitLab's documentation uses for the code quality artifact.
+const DefaultCodeQualityReportPath = "gl-code-quality-report.json"
+
+// CodeQualityIssue is one issue of a GitLab Code Quality report, a subset of the
+// CodeClimate issue format.
+type CodeQualityIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation points an issue at a file and a range of lines.
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// CodeQualityReport writes the below-threshold grades of the latest histories as a GitLab
+// Code Quality report, which merge request widgets show as new and resolved issues.
+type CodeQualityReport struct {
+	OutputPath string
+	Calculator filter.ToolGradeCalculator
+}
+
+func NewCodeQualityReport(outputPath string, calculator filter.ToolGradeCalculator) *CodeQualityReport {
+	return &CodeQualityReport{OutputPath: outputPath, Calculator: calculator}
+}
+
+// GenerateCodeQuality writes the issues of the histories graded below their threshold to
+// OutputPath.
+func (c *CodeQualityReport) GenerateCodeQuality(histories filter.Histories, thresholds filter.ThresholdResolver) error {
+	issues := BuildCodeQualityIssues(histories, thresholds, c.Calculator)
+
+	if err := os.MkdirAll(filepath.Dir(c.OutputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory '%s': %w", filepath.Dir(c.OutputPath), err)
+	}
+	encoded, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode code quality report: %w", err)
+	}
+	if err := os.WriteFile(c.OutputPath, append(encoded, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write code quality output file '%s': %w", c.OutputPath, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully generated code quality report with %d issue(s): %s\n", len(issues), c.OutputPath)
+	return nil
+}
+
+// BuildCodeQualityIssues turns every file/tool grade below its threshold into issues: one
+// per unresolved review task, or a single issue for the grade when the review has no tasks.
+// Fingerprints are derived from tool, path and task, so they stay the same across runs and
+// branches while the finding is unchanged.
+func BuildCodeQualityIssues(histories filter.Histories, thresholds filter.ThresholdResolver, calculator filter.ToolGradeCalculator) []CodeQualityIssue {
+	sorted := append(filter.Histories{}, histories...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].FilePath != sorted[j].FilePath {
+			return sorted[i].FilePath < sorted[j].FilePath
+		}
+		return sorted[i].AssessingTool < sorted[j].AssessingTool
+	})
+
+	issues := []CodeQualityIssue{}
+	occurrences := make(map[string]int)
+	for _, history := range sorted {
+		thresholdGrade := thresholds.Resolve(history.AssessingTool, history.FilePath).Grade
+		gap := calculator.GradeNumericalValue(thresholdGrade) - calculator.ToolGradeValue(history.AssessingTool, history.Grade)
+		if gap <= 0 {
+			continue
+		}
+		severity := codeQualitySeverity(gap)
+		summary := fmt.Sprintf("%s grade %s is below threshold %s", history.AssessingTool, history.Grade, thresholdGrade)
+
+		taskIssues := 0
+		for _, task := range history.ReviewTasks() {
+			if task.Resolved() || task.TitleTask == "" {
+				continue
+			}
+			taskIssues++
+			issues = append(issues, CodeQualityIssue{
+				Type:        "issue",
+				CheckName:   history.AssessingTool + "/" + task.TitleTask,
+				Description: summary + ": " + task.TitleTask,
+				Categories:  codeQualityCategories(history.AssessingTool),
+				Severity:    severity,
+				Fingerprint: uniqueFingerprint(taskFingerprint(history, task), occurrences),
+				Location:    CodeQualityLocation{Path: history.FilePath, Lines: taskLines(task, history.CodeDiff)},
+			})
+		}
+		if taskIssues > 0 {
+			continue
+		}
+
+		description := summary
+		if review := history.ReviewSummary(); review != "" {
+			description += ": " + review
+		}
+		issues = append(issues, CodeQualityIssue{
+			Type:        "issue",
+			CheckName:   history.AssessingTool + "/grade",
+			Description: description,
+			Categories:  codeQualityCategories(history.AssessingTool),
+			Severity:    severity,
+			Fingerprint: gradeFingerprint(history),
+			Location:    CodeQualityLocation{Path: history.FilePath, Lines: diffLines(history.CodeDiff)},
+		})
+	}
+	return issues
+}
+
+// codeQualitySeverity grows with the number of grade steps the grade sits below the
+// threshold, e.g. one step (B- against B) is minor.
+func codeQualitySeverity(gap int) string {
+	switch {
+	case gap <= 1:
+		return "minor"
+	case gap <= 3:
+		return "major"
+	case gap <= 5:
+		return "critical"
+	default:
+		return "blocker"
+	}
+}
+
+// codeQualityCategories maps a tool onto the CodeClimate category of its findings.
+func codeQualityCategories(tool string) []string {
+	switch strings.ToUpper(tool) {
+	case "OWASP-TOP-10", "CWE-TOP-25":
+		return []string{"Security"}
+	case "COMPLEXITY", "COMPLEXITY-PRO":
+		return []string{"Complexity"}
+	case "MISRA-C++":
+		return []string{"Bug Risk"}
+	case "CLEAN-CODE":
+		return []string{"Clarity"}
+	default:
+		return []string{"Style"}
+	}
+}
+
+// taskLines is the line range taskRegion finds for the task. Code Quality issues need a line,
+// so a task that cannot be located points at the first line of the file.
+func taskLines(task filter.ReviewTask, diff filter.CodeDiff) CodeQualityLines {
+	if region := taskRegion(task, diff); region != nil {
+		return CodeQualityLines{Begin: region.StartLine, End: region.EndLine}
+	}
+	return CodeQualityLines{Begin: 1, End: 1}
+}
+
+// diffLines spans the changes of the codeDiff, or is the first line of the file without one.
+func diffLines(diff filter.CodeDiff) CodeQualityLines {
+	start, end := diffRange(diff)
+	if start <= 0 {
+		return CodeQualityLines{Begin: 1, End: 1}
+	}
+	return CodeQualityLines{Begin: start, End: end}
+}
+
+// uniqueFingerprint keeps fingerprints unique when a review repeats a task: the second
+// occurrence gets a suffix, which stays stable as long as the order of the tasks does.
+func uniqueFingerprint(fingerprint string, occurrences map[string]int) string {
+	occurrences[fingerprint]++
+	if count := occurrences[fingerprint]; count > 1 {
+		return fmt.Sprintf("%s-%d", fingerprint, count)
+	}
+	return fingerprint
+}
+
+// gradeFingerprint identifies the grade issue of a file and tool. It does not include the
+// grade itself, so an issue whose grade improves but stays below threshold is not "new".
+func gradeFingerprint(history filter.History) string {
+	digest := sha256.Sum256([]byte(history.FilePath + "|" + history.AssessingTool + "|grade"))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildCodeQualityIssues(t *testing.T) {
+	gradeOnly := reviewedHistory("c.go", "OWASP-TOP-10", "F")
+	gradeOnly.CodeDiff = filter.CodeDiff{Changes: []filter.CodeChange{{Start: 20, End: 25}, {Start: 4, End: 2}}}
+	changed := reviewedHistory("d.go", "SOLID", "C", map[string]any{"titleTask": "Name things", "violatingCode": "x := 1"})
+	changed.CodeDiff = filter.CodeDiff{Changes: []filter.CodeChange{{Start: 7, End: 9, OldCode: "x  :=  1"}}}
+	histories := filter.Histories{
+		reviewedHistory("b.go", "SOLID", "C",
+			map[string]any{"titleTask": "Split handler", "lineStart": 10.0, "lineEnd": 12.0},
+			map[string]any{"titleTask": "Split handler"},
+			map[string]any{"titleTask": "Done already", "done": true},
+		),
+		reviewedHistory("a.go", "SOLID", "A", map[string]any{"titleTask": "Passing grades have no issues"}),
+		gradeOnly,
+		changed,
+	}
+	thresholds := filter.NewThresholds(filter.Threshold{Grade: "B"}, nil)
+
+	issues := BuildCodeQualityIssues(histories, thresholds, filter.NewGradeScales(nil))
+
+	expected := []struct {
+		checkName  string
+		path       string
+		severity   string
+		category   string
+		begin, end int
+	}{
+		{"SOLID/Split handler", "b.go", "major", "Style", 10, 12},
+		{"SOLID/Split handler", "b.go", "major", "Style", 1, 1}, // Neither its own lines nor a codeDiff
+		{"OWASP-TOP-10/grade", "c.go", "blocker", "Security", 4, 25},
+		{"SOLID/Name things", "d.go", "major", "Style", 7, 9},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("issues = %+v, expected %d", issues, len(expected))
+	}
+	fingerprints := make(map[string]bool)
+	for i, issue := range issues {
+		want := expected[i]
+		if issue.CheckName != want.checkName || issue.Location.Path != want.path || issue.Severity != want.severity || issue.Categories[0] != want.category {
+			t.Errorf("issue %d = %+v, expected %+v", i, issue, want)
+		}
+		if issue.Location.Lines.Begin != want.begin || issue.Location.Lines.End != want.end {
+			t.Errorf("issue %d spans lines %+v, expected %d-%d", i, issue.Location.Lines, want.begin, want.end)
+		}
+		if fingerprints[issue.Fingerprint] {
+			t.Errorf("issue %d repeats fingerprint %s", i, issue.Fingerprint)
+		}
+		fingerprints[issue.Fingerprint] = true
+	}
+	if issues[0].Description != "SOLID grade C is below threshold B: Split handler" {
+		t.Errorf("task issue description = %q, expected the task title", issues[0].Description)
+	}
+	if !strings.HasSuffix(issues[2].Description, ": Review of c.go") {
+		t.Errorf("grade issue description = %q, expected it to end with the review summary", issues[2].Description)
+	}
+}
+
+func TestBuildCodeQualityIssuesAtThreshold(t *testing.T) {
+	histories := filter.Histories{reviewedHistory("a.go", "SOLID", "C")}
+
+	lenient := BuildCodeQualityIssues(histories, filter.NewThresholds(filter.Threshold{Grade: "C"}, nil), filter.NewGradeScales(nil))
+	strict := BuildCodeQualityIssues(histories, filter.NewThresholds(filter.Threshold{Grade: "C+"}, nil), filter.NewGradeScales(nil))
+	if len(lenient) != 0 || len(strict) != 1 || strict[0].Severity != "minor" {
+		t.Errorf("lenient %+v, strict %+v: expected no issue at the threshold and a minor one a step below", lenient, strict)
+	}
+}
+
+func TestCodeQualitySeverity(t *testing.T) {
+	tests := []struct {
+		gap      int
+		expected string
+	}{
+		{1, "minor"},
+		{2, "major"},
+		{3, "major"},
+		{4, "critical"},
+		{5, "critical"},
+		{6, "blocker"},
+		{12, "blocker"},
+	}
+	for _, tt := range tests {
+		if got := codeQualitySeverity(tt.gap); got != tt.expected {
+			t.Errorf("codeQualitySeverity(%d) = %q, expected %q", tt.gap, got, tt.expected)
+		}
+	}
+}
+
+func TestCodeQualityCategories(t *testing.T) {
+	tests := map[string]string{
+		"CWE-Top-25":     "Security",
+		"Complexity-Pro": "Complexity",
+		"MISRA-C++":      "Bug Risk",
+		"Clean-Code":     "Clarity",
+		"SOLID":          "Style",
+	}
+	for tool, expected := range tests {
+		if got := codeQualityCategories(tool); len(got) != 1 || got[0] != expected {
+			t.Errorf("codeQualityCategories(%q) = %v, expected [%s]", tool, got, expected)
+		}
+	}
+}
+
+func TestCodeQualityReportGenerateCodeQuality(t *testing.T) {
+	tests := []struct {
+		name      string
+		histories filter.Histories
+		issues    int
+	}{
+		{"no issues writes an empty list", filter.Histories{reviewedHistory("a.go", "SOLID", "A")}, 0},
+		{"issues", filter.Histories{reviewedHistory("a.go", "SOLID", "F")}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "out", DefaultCodeQualityReportPath)
+			report := NewCodeQualityReport(outputPath, filter.NewGradeScales(nil))
+
+			if err := report.GenerateCodeQuality(tt.histories, filter.NewThresholds(filter.Threshold{Grade: "B"}, nil)); err != nil {
+				t.Fatalf("GenerateCodeQuality: %v", err)
+			}
+			data, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("reading the report: %v", err)
+			}
+			var issues []CodeQualityIssue
+			if err := json.Unmarshal(data, &issues); err != nil || issues == nil {
+				t.Fatalf("decoding the report %q: %v, expected a JSON array", data, err)
+			}
+			if len(issues) != tt.issues {
+				t.Errorf("%d issues, expected %d", len(issues), tt.issues)
+			}
+		})
+	}
+}
@@ -4,21 +4,48 @@ import (
 	"codeleft-cli/filter"
 )
 
+// Default output locations for the generated reports.
+const (
+	DefaultHTMLReportPath = "CodeLeft-Coverage-Report.html"
+	DefaultJSONReportPath = "CodeLeft-Coverage-Report.json"
+)
+
 type IReport interface {
-	GenerateReport(gradeDetails []filter.GradeDetails, threshold string) error
+	GenerateReport(gradeDetails []filter.GradeDetails, threshold string, weigher filter.CoverageWeigher) error
 }
 
 type HtmlReport struct {
 	ReportType string
+	OutputPath string
 }
 
-func NewHtmlReport() IReport {
+func NewHtmlReport(outputPath string) IReport {
 	return &HtmlReport{
 		ReportType: "HTML",
+		OutputPath: outputPath,
 	}
 }
 
+func (h *HtmlReport) GenerateReport(gradeDetails []filter.GradeDetails, threshold string, weigher filter.CoverageWeigher) error {
+	writer, err := NewHTMLReportWriter()
+	if err != nil {
+		return err
+	}
+	return GenerateReport(gradeDetails, h.OutputPath, threshold, weigher, writer)
+}
 
-func (h *HtmlReport) GenerateReport(gradeDetails []filter.GradeDetails, threshold string) error {
-	return GenerateRepoHTMLReport(gradeDetails, "CodeLeft-Coverage-Report.html", threshold)
-}
\ No newline at end of file
+type JsonReport struct {
+	ReportType string
+	OutputPath string
+}
+
+func NewJsonReport(outputPath string) IReport {
+	return &JsonReport{
+		ReportType: "JSON",
+		OutputPath: outputPath,
+	}
+}
+
+func (j *JsonReport) GenerateReport(gradeDetails []filter.GradeDetails, threshold string, weigher filter.CoverageWeigher) error {
+	return GenerateReport(gradeDetails, j.OutputPath, threshold, weigher, NewJSONReportWriter())
+}
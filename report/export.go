@@ -0,0 +1,270 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export columns. Every gradingDetails sub-score is a column of its own, named by its
+// dotted path, e.g. "gradingDetails.grades.readability".
+const (
+	ExportColumnPath           = "path"
+	ExportColumnTool           = "tool"
+	ExportColumnGrade          = "grade"
+	ExportColumnScore          = "score"
+	ExportColumnCoverage       = "coverage"
+	ExportColumnUsername       = "username"
+	ExportColumnTimestamp      = "timestamp"
+	ExportColumnHash           = "hash"
+	ExportColumnGradingDetails = "gradingDetails"
+)
+
+// DefaultExportColumns are the columns exported when none are selected.
+var DefaultExportColumns = []string{
+	ExportColumnPath, ExportColumnTool, ExportColumnGrade, ExportColumnScore, ExportColumnCoverage,
+	ExportColumnUsername, ExportColumnTimestamp, ExportColumnHash,
+}
+
+// ExportRow is one exported grade: a latest grade or a single history record.
+type ExportRow struct {
+	Path           string
+	Tool           string
+	Grade          string
+	Score          string // Empty when the file has no grade
+	Coverage       string // Empty when it cannot be computed
+	Username       string
+	Timestamp      string
+	Hash           string
+	GradingDetails map[string]string // Flattened sub-scores keyed by their dotted path
+}
+
+// NewLatestExportRows builds a row for each grade detail, completed with the history
+// record it was collected from. Grades that are not on their tool's scale have no score or
+// coverage.
+func NewLatestExportRows(details []filter.GradeDetails, latest filter.Histories, scales filter.GradeScaleCalculator) []ExportRow {
+	records := make(map[string]filter.History)
+	for _, history := range latest {
+		records[history.FilePath+"|"+history.AssessingTool] = history
+	}
+
+	rows := []ExportRow{}
+	for _, detail := range details {
+		row := ExportRow{Path: detail.FileName, Tool: detail.Tool, GradingDetails: map[string]string{}}
+		switch {
+		case detail.Unassessed:
+			row.Coverage = strconv.Itoa(detail.Coverage)
+		case scales.IsKnown(detail.Tool, detail.Grade):
+			row.Grade = detail.Grade
+			row.Score = strconv.Itoa(detail.Score)
+			row.Coverage = strconv.Itoa(detail.Coverage)
+		default:
+			row.Grade = detail.Grade
+		}
+		if history, ok := records[detail.FileName+"|"+detail.Tool]; ok {
+			row.Username = history.Username
+			row.Timestamp = history.TimeStamp.Format(time.RFC3339)
+			row.Hash = history.Hash
+			row.GradingDetails = FlattenGradingDetails(history.GradingDetails)
+		}
+		rows = append(rows, row)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Path != rows[j].Path {
+			return rows[i].Path < rows[j].Path
+		}
+		return rows[i].Tool < rows[j].Tool
+	})
+	return rows
+}
+
+// NewHistoryExportRows builds a row for every history record, in file order. Coverage is
+// computed against the record's threshold grade, and left empty when it is not a known grade.
+// Records whose grade is not on their tool's scale have neither score nor coverage.
+func NewHistoryExportRows(histories filter.Histories, calculator filter.GradeScaleCalculator, coverageCalculator filter.ICoverageCalculator, thresholds filter.ThresholdResolver) []ExportRow {
+	rows := []ExportRow{}
+	for _, history := range histories {
+		row := ExportRow{
+			Path:           history.FilePath,
+			Tool:           history.AssessingTool,
+			Grade:          history.Grade,
+			Username:       history.Username,
+			Timestamp:      history.TimeStamp.Format(time.RFC3339),
+			Hash:           history.Hash,
+			GradingDetails: FlattenGradingDetails(history.GradingDetails),
+		}
+		if !calculator.IsKnown(history.AssessingTool, history.Grade) {
+			rows = append(rows, row)
+			continue
+		}
+		score := calculator.ToolGradeValue(history.AssessingTool, history.Grade)
+		row.Score = strconv.Itoa(score)
+		if thresholdGrade := thresholds.Resolve(history.AssessingTool, history.FilePath).Grade; filter.IsKnownGrade(thresholdGrade) {
+			row.Coverage = strconv.Itoa(coverageCalculator.CalculateCoverage(score, calculator.GradeNumericalValue(thresholdGrade)))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// FlattenGradingDetails turns the nested gradingDetails of a record into dotted column
+// names, e.g. {"grades": {"readability": "A"}} becomes "gradingDetails.grades.readability".
+func FlattenGradingDetails(details map[string]any) map[string]string {
+	flat := make(map[string]string)
+	flattenInto(flat, ExportColumnGradingDetails, details)
+	return flat
+}
+
+func flattenInto(flat map[string]string, prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			flattenInto(flat, prefix+"."+key, child)
+		}
+	case nil:
+	case string:
+		flat[prefix] = v
+	case float64:
+		flat[prefix] = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		flat[prefix] = fmt.Sprint(v)
+	}
+}
+
+// ParseExportColumns splits a comma-separated column list. An empty list selects the
+// default columns.
+func ParseExportColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return DefaultExportColumns, nil
+	}
+	columns := []string{}
+	for _, column := range strings.Split(spec, ",") {
+		column = strings.TrimSpace(column)
+		if !isExportColumn(column) {
+			return nil, fmt.Errorf("unknown column %q: expected %s, %s or %s.<sub-score>", column, strings.Join(DefaultExportColumns, ", "), ExportColumnGradingDetails, ExportColumnGradingDetails)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func isExportColumn(column string) bool {
+	if column == ExportColumnGradingDetails || strings.HasPrefix(column, ExportColumnGradingDetails+".") {
+		return true
+	}
+	for _, known := range DefaultExportColumns {
+		if column == known {
+			return true
+		}
+	}
+	return false
+}
+
+// TabularExporter writes export rows as delimiter-separated values, quoted as RFC 4180
+// describes.
+type TabularExporter struct {
+	Delimiter rune
+	Columns   []string
+}
+
+// NewCSVExporter writes comma-separated values with CRLF line endings.
+func NewCSVExporter(columns []string) *TabularExporter {
+	return &TabularExporter{Delimiter: ',', Columns: columns}
+}
+
+// NewTSVExporter writes tab-separated values.
+func NewTSVExporter(columns []string) *TabularExporter {
+	return &TabularExporter{Delimiter: '\t', Columns: columns}
+}
+
+// Export writes a header row and one line per row. A gradingDetails column, or a prefix
+// of sub-scores such as "gradingDetails.grades", expands to every matching sub-score found
+// in the rows, in alphabetical order.
+func (e *TabularExporter) Export(w io.Writer, rows []ExportRow) error {
+	columns := expandExportColumns(e.Columns, rows)
+
+	writer := csv.NewWriter(w)
+	writer.Comma = e.Delimiter
+	writer.UseCRLF = e.Delimiter == ','
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("error writing export header: %w", err)
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row.value(column)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing export row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing export: %w", err)
+	}
+	return nil
+}
+
+func (r ExportRow) value(column string) string {
+	switch column {
+	case ExportColumnPath:
+		return r.Path
+	case ExportColumnTool:
+		return r.Tool
+	case ExportColumnGrade:
+		return r.Grade
+	case ExportColumnScore:
+		return r.Score
+	case ExportColumnCoverage:
+		return r.Coverage
+	case ExportColumnUsername:
+		return r.Username
+	case ExportColumnTimestamp:
+		return r.Timestamp
+	case ExportColumnHash:
+		return r.Hash
+	default:
+		return r.GradingDetails[column]
+	}
+}
+
+// expandExportColumns replaces each gradingDetails column that is not itself a sub-score
+// with the sub-scores below it. A column that matches no sub-score is kept as it is.
+func expandExportColumns(columns []string, rows []ExportRow) []string {
+	subScores := map[string]struct{}{}
+	for _, row := range rows {
+		for key := range row.GradingDetails {
+			subScores[key] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(subScores))
+	for key := range subScores {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	expanded := []string{}
+	for _, column := range columns {
+		_, isSubScore := subScores[column]
+		if !strings.HasPrefix(column, ExportColumnGradingDetails) || isSubScore {
+			expanded = append(expanded, column)
+			continue
+		}
+		matched := false
+		for _, key := range sorted {
+			if strings.HasPrefix(key, column+".") {
+				expanded = append(expanded, key)
+				matched = true
+			}
+		}
+		if !matched {
+			expanded = append(expanded, column) // Keep the column, empty, so the layout is predictable
+		}
+	}
+	return expanded
+}
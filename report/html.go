@@ -13,28 +13,35 @@ import (
 
 // ReportNode represents a node (file or directory) in the report tree.
 type ReportNode struct {
-	Name           string
-	Path           string                // Full path relative to root
-	IsDir          bool
-	Details        []filter.GradeDetails // Stores ALL GradeDetails for this file (if IsDir is false)
-	Children       []*ReportNode         // Populated for directories (using pointers)
-	Coverage       float64               // Calculated OVERALL coverage for this node
-	CoverageOk     bool                  // Flag if overall coverage was calculable
-	ToolCoverages  map[string]float64    // Coverage per tool (file's tool coverage OR directory's average coverage per tool)
-	ToolCoverageOk map[string]bool       // Flag if coverage for a specific tool was calculable/present
+	Name            string                `json:"name"`
+	Path            string                `json:"path"` // Full path relative to root
+	IsDir           bool                  `json:"isDir"`
+	Details         []filter.GradeDetails `json:"details,omitempty"`         // Stores ALL GradeDetails for this file (if IsDir is false)
+	Children        []*ReportNode         `json:"children,omitempty"`        // Populated for directories (using pointers)
+	Coverage        float64               `json:"coverage"`                  // Calculated OVERALL coverage for this node
+	CoverageOk      bool                  `json:"coverageOk"`                // Flag if overall coverage was calculable
+	ToolCoverages   map[string]float64    `json:"toolCoverages,omitempty"`   // Coverage per tool (file's tool coverage OR directory's average coverage per tool)
+	ToolCoverageOk  map[string]bool       `json:"toolCoverageOk,omitempty"`  // Flag if coverage for a specific tool was calculable/present
+	StaleTools      []string              `json:"staleTools,omitempty"`      // Tools whose grade was recorded for an older version of this file
+	UnassessedTools []string              `json:"unassessedTools,omitempty"` // Requested tools that never graded this file
+	weight          float64               // Weight of this node in its parent's coverage
+	toolWeights     map[string]float64    // Weight of this node in its parent's coverage per tool
 }
 
 // ReportViewData holds all data needed by the HTML template.
 type ReportViewData struct {
-	RootNodes       []*ReportNode      // Top-level files/dirs (using pointers)
-	AllTools        []string           // Sorted list of unique tools found
-	OverallAverages map[string]float64 // Average coverage per tool across ALL files
-	TotalAverage    float64            // Overall average coverage across ALL files/tools
-	ThresholdGrade  string             // The threshold grade used for calculations
+	RootNodes       []*ReportNode      `json:"rootNodes"`       // Top-level files/dirs (using pointers)
+	AllTools        []string           `json:"allTools"`        // Sorted list of unique tools found
+	OverallAverages map[string]float64 `json:"overallAverages"` // Average coverage per tool across ALL files
+	TotalAverage    float64            `json:"totalAverage"`    // Overall average coverage across ALL files/tools
+	ThresholdGrade  string             `json:"thresholdGrade"`  // The threshold grade used for calculations
 }
 
 // GenerateRepoHTMLReport generates the HTML report.
 // Takes a slice of GradeDetail structs as input.
+//
+// Deprecated: use GenerateReport with an HTMLReportWriter, which honours the coverage and
+// weighting sections of config.json. This function always uses the default coverage steps.
 func GenerateRepoHTMLReport(gradeDetails []filter.GradeDetails, outputPath string, thresholdGrade string) error {
 	if len(gradeDetails) == 0 {
 		log.Println("Warning: No grade details provided to generate report.")
@@ -53,7 +60,7 @@ func GenerateRepoHTMLReport(gradeDetails []filter.GradeDetails, outputPath strin
 	//    and collect global stats (tool names, sums for overall averages).
 	toolSet := make(map[string]struct{})
 	globalToolCoverageSums := make(map[string]float64) // Sum of coverage per tool across ALL FILES
-	globalToolFileCounts := make(map[string]int)     // Files assessed per tool across ALL FILES
+	globalToolFileCounts := make(map[string]int)       // Files assessed per tool across ALL FILES
 
 	for _, node := range rootNodes {
 		calculateNodeCoverages(node, groupedDetails, thresholdGrade, toolSet, globalToolCoverageSums, globalToolFileCounts)
@@ -92,8 +99,7 @@ func GenerateRepoHTMLReport(gradeDetails []filter.GradeDetails, outputPath strin
 			if _, toolDone := processedToolsThisFile[detail.Tool]; toolDone {
 				continue // Skip if we already processed this tool for this file
 			}
-			if detail.Tool != "" && detail.Grade != "" {
-				cov := filter.CalculateCoverageScore(detail.Grade, thresholdGrade)
+			if cov, ok := defaultCoverage(detail.Grade, thresholdGrade); detail.Tool != "" && ok {
 				fileCoverageSum += cov
 				fileToolCount++
 				processedToolsThisFile[detail.Tool] = struct{}{}
@@ -292,7 +298,10 @@ func calculateNodeCoverages(
 				continue // Only count first entry for a tool for this specific file node calculation
 			}
 
-			coverage := filter.CalculateCoverageScore(detail.Grade, thresholdGrade)
+			coverage, ok := defaultCoverage(detail.Grade, thresholdGrade)
+			if !ok {
+				continue // Grades off the built-in scale have no coverage
+			}
 			node.ToolCoverages[detail.Tool] = coverage
 			node.ToolCoverageOk[detail.Tool] = true
 			toolSet[detail.Tool] = struct{}{} // Add tool to global set
@@ -364,6 +373,17 @@ func calculateNodeCoverages(
 	}
 }
 
+// defaultCoverage is the coverage of grade against thresholdGrade from the default
+// coverage calculator, and false when either grade is not on the built-in scale.
+func defaultCoverage(grade string, thresholdGrade string) (float64, bool) {
+	score, ok := filter.GetGradeIndex(grade)
+	threshold, thresholdOk := filter.GetGradeIndex(thresholdGrade)
+	if !ok || !thresholdOk {
+		return 0, false
+	}
+	return float64(filter.NewDefaultCoverageCalculator().CalculateCoverage(score, threshold)), true
+}
+
 // sortReportNodes recursively sorts children nodes: directories first, then alphabetically.
 func sortReportNodes(nodes []*ReportNode) {
 	// Sort the current level
@@ -380,4 +400,4 @@ func sortReportNodes(nodes []*ReportNode) {
 			sortReportNodes(node.Children)
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,208 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultMarkdownReportPath is where "report markdown" writes by default.
+const DefaultMarkdownReportPath = "CodeLeft-Summary.md"
+
+// DefaultWorstFiles is how many of the lowest-covered files the Markdown summary lists.
+const DefaultWorstFiles = 10
+
+// MarkdownReport writes a compact Markdown summary of the report, for pull request
+// comments and GitHub step summaries.
+type MarkdownReport struct {
+	OutputPath string
+	WorstFiles int
+	Append     bool // Append to OutputPath, as $GITHUB_STEP_SUMMARY expects, instead of replacing it
+}
+
+func NewMarkdownReport(outputPath string, worstFiles int, appendToFile bool) IReport {
+	return &MarkdownReport{OutputPath: outputPath, WorstFiles: worstFiles, Append: appendToFile}
+}
+
+func (m *MarkdownReport) GenerateReport(gradeDetails []filter.GradeDetails, threshold string, weigher filter.CoverageWeigher) error {
+	return GenerateReport(gradeDetails, m.OutputPath, threshold, weigher, NewMarkdownReportWriter(m.WorstFiles, m.Append))
+}
+
+// MarkdownReportWriter renders the report view data as Markdown.
+type MarkdownReportWriter struct {
+	WorstFiles int
+	Append     bool
+}
+
+func NewMarkdownReportWriter(worstFiles int, appendToFile bool) *MarkdownReportWriter {
+	return &MarkdownReportWriter{WorstFiles: worstFiles, Append: appendToFile}
+}
+
+func (w *MarkdownReportWriter) Write(data ReportViewData, outputPath string) error {
+	outputDir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory '%s': %w", outputDir, err)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if w.Append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	outputFile, err := os.OpenFile(outputPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open Markdown output file '%s': %w", outputPath, err)
+	}
+	defer outputFile.Close()
+
+	if _, err := outputFile.WriteString(RenderMarkdown(data, w.WorstFiles)); err != nil {
+		return fmt.Errorf("failed to write Markdown report: %w", err)
+	}
+	return nil
+}
+
+// RenderMarkdown renders the per-tool averages, the overall average against the threshold,
+// the worstFiles lowest-covered files and a collapsible per-directory breakdown.
+func RenderMarkdown(data ReportViewData, worstFiles int) string {
+	var b strings.Builder
+	b.WriteString("## CodeLeft summary\n\n")
+
+	if len(data.RootNodes) == 0 {
+		b.WriteString("No grades to summarise.\n\n")
+		return b.String()
+	}
+
+	verdict := "meets"
+	if data.TotalAverage < 100 {
+		verdict = "is below"
+	}
+	fmt.Fprintf(&b, "%s Overall coverage **%s** %s the threshold grade **%s** (100%% means every grade is at the threshold).\n\n",
+		coverageIndicator(data.TotalAverage), formatPercent(data.TotalAverage), verdict, markdownEscape(data.ThresholdGrade))
+
+	b.WriteString("| Tool | Average coverage |\n|------|-----------------:|\n")
+	for _, tool := range data.AllTools {
+		average := data.OverallAverages[tool]
+		fmt.Fprintf(&b, "| %s | %s %s |\n", markdownEscape(tool), coverageIndicator(average), formatPercent(average))
+	}
+	fmt.Fprintf(&b, "| **Overall** | %s **%s** |\n\n", coverageIndicator(data.TotalAverage), formatPercent(data.TotalAverage))
+
+	files := worstFileNodes(data.RootNodes, worstFiles)
+	if len(files) > 0 {
+		fmt.Fprintf(&b, "### Lowest coverage\n\n| File | Coverage | Grades |\n|------|---------:|--------|\n")
+		for _, node := range files {
+			fmt.Fprintf(&b, "| `%s` | %s %s | %s |\n", markdownEscape(node.Path), coverageIndicator(node.Coverage), formatPercent(node.Coverage), fileGrades(node))
+		}
+		b.WriteString("\n")
+	}
+
+	dirs := directoryNodes(data.RootNodes)
+	if len(dirs) > 0 {
+		b.WriteString("<details>\n<summary>Coverage per directory</summary>\n\n| Directory | Overall |")
+		for _, tool := range data.AllTools {
+			fmt.Fprintf(&b, " %s |", markdownEscape(tool))
+		}
+		b.WriteString("\n|-----------|--------:|")
+		for range data.AllTools {
+			b.WriteString("---:|")
+		}
+		b.WriteString("\n")
+		for _, dir := range dirs {
+			fmt.Fprintf(&b, "| `%s/` | %s |", markdownEscape(dir.Path), nodeCoverage(dir.Coverage, dir.CoverageOk))
+			for _, tool := range data.AllTools {
+				fmt.Fprintf(&b, " %s |", nodeCoverage(dir.ToolCoverages[tool], dir.ToolCoverageOk[tool]))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n</details>\n\n")
+	}
+	return b.String()
+}
+
+// worstFileNodes returns up to limit file nodes with the lowest coverage, lowest first.
+func worstFileNodes(nodes []*ReportNode, limit int) []*ReportNode {
+	files := []*ReportNode{}
+	walkReportNodes(nodes, func(node *ReportNode) {
+		if !node.IsDir && node.CoverageOk {
+			files = append(files, node)
+		}
+	})
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].Coverage != files[j].Coverage {
+			return files[i].Coverage < files[j].Coverage
+		}
+		return files[i].Path < files[j].Path
+	})
+	if limit >= 0 && len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
+
+// directoryNodes returns every directory node in the order of the tree.
+func directoryNodes(nodes []*ReportNode) []*ReportNode {
+	dirs := []*ReportNode{}
+	walkReportNodes(nodes, func(node *ReportNode) {
+		if node.IsDir {
+			dirs = append(dirs, node)
+		}
+	})
+	return dirs
+}
+
+// walkReportNodes visits every node depth-first, parents before their children.
+func walkReportNodes(nodes []*ReportNode, visit func(node *ReportNode)) {
+	for _, node := range nodes {
+		visit(node)
+		walkReportNodes(node.Children, visit)
+	}
+}
+
+// fileGrades lists each tool's grade of a file node, marking stale and unassessed ones.
+func fileGrades(node *ReportNode) string {
+	grades := []string{}
+	for _, detail := range node.Details {
+		switch {
+		case detail.Unassessed:
+			grades = append(grades, detail.Tool+" unassessed")
+		case detail.Stale:
+			grades = append(grades, detail.Tool+" "+detail.Grade+" (stale)")
+		default:
+			grades = append(grades, detail.Tool+" "+detail.Grade)
+		}
+	}
+	sort.Strings(grades)
+	return markdownEscape(strings.Join(grades, ", "))
+}
+
+func nodeCoverage(coverage float64, ok bool) string {
+	if !ok {
+		return "–"
+	}
+	return formatPercent(coverage)
+}
+
+func formatPercent(coverage float64) string {
+	return fmt.Sprintf("%.2f%%", coverage)
+}
+
+// coverageIndicator is a coloured dot following the same steps as the HTML report's
+// coverage colours.
+func coverageIndicator(coverage float64) string {
+	switch {
+	case coverage >= 100:
+		return "🟢"
+	case coverage >= 70:
+		return "🟡"
+	case coverage >= 30:
+		return "🟠"
+	default:
+		return "🔴"
+	}
+}
+
+// markdownEscape keeps text from breaking out of a table cell.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// markdownViewData builds the view data of the report for details, thresholded at B.
+func markdownViewData(t *testing.T, details []filter.GradeDetails) ReportViewData {
+	t.Helper()
+	writer := &capturingWriter{}
+	if err := GenerateReport(details, "CodeLeft-Summary.md", "B", filter.NewUniformWeigher(), writer); err != nil {
+		t.Fatalf("GenerateReport: %v", err)
+	}
+	return writer.data
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	data := markdownViewData(t, []filter.GradeDetails{
+		{FileName: "cli/a.go", Tool: "SOLID", Grade: "A", Coverage: 100},
+		{FileName: "cli/a.go", Tool: "OWASP-TOP-10", Grade: "C", Coverage: 40, Stale: true},
+		{FileName: "cli/b|c.go", Tool: "SOLID", Grade: "B", Coverage: 80},
+		{FileName: "filter/d.go", Tool: "SOLID", Unassessed: true},
+	})
+	markdown := RenderMarkdown(data, 2)
+
+	expected := []string{
+		"## CodeLeft summary\n\n",
+		"🟠 Overall coverage **55.00%** is below the threshold grade **B**",
+		"| OWASP-TOP-10 | 🟠 40.00% |\n",
+		"| SOLID | 🟠 60.00% |\n",
+		"| **Overall** | 🟠 **55.00%** |\n",
+		"### Lowest coverage\n\n| File | Coverage | Grades |\n|------|---------:|--------|\n" +
+			"| `filter/d.go` | 🔴 0.00% | SOLID unassessed |\n" +
+			"| `cli/a.go` | 🟡 70.00% | OWASP-TOP-10 C (stale), SOLID A |\n\n",
+		"<details>\n<summary>Coverage per directory</summary>\n\n| Directory | Overall | OWASP-TOP-10 | SOLID |\n",
+		"| `cli/` | 75.00% | 40.00% | 90.00% |\n", // Directories average their files
+		"| `filter/` | 0.00% | – | 0.00% |\n",
+	}
+	for _, text := range expected {
+		if !strings.Contains(markdown, text) {
+			t.Errorf("summary does not contain %q:\n%s", text, markdown)
+		}
+	}
+	if strings.Contains(markdown, "b|c.go") {
+		t.Errorf("a | in a file name was not escaped:\n%s", markdown)
+	}
+
+	if all := RenderMarkdown(data, -1); !strings.Contains(all, "| `cli/b\\|c.go` | 🟡 80.00% | SOLID B |") {
+		t.Errorf("a negative limit does not list every file:\n%s", all)
+	}
+}
+
+func TestRenderMarkdownMeetsThreshold(t *testing.T) {
+	markdown := RenderMarkdown(markdownViewData(t, []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Grade: "A", Coverage: 110}}), DefaultWorstFiles)
+	if !strings.Contains(markdown, "🟢 Overall coverage **110.00%** meets the threshold grade **B**") {
+		t.Errorf("summary does not meet the threshold:\n%s", markdown)
+	}
+	if strings.Contains(markdown, "<details>") {
+		t.Errorf("summary without directories has a directory breakdown:\n%s", markdown)
+	}
+
+	if empty := RenderMarkdown(ReportViewData{}, DefaultWorstFiles); empty != "## CodeLeft summary\n\nNo grades to summarise.\n\n" {
+		t.Errorf("empty summary = %q", empty)
+	}
+}
+
+func TestMarkdownReportWriterWrite(t *testing.T) {
+	data := markdownViewData(t, []filter.GradeDetails{{FileName: "a.go", Tool: "SOLID", Grade: "A", Coverage: 100}})
+	summary := RenderMarkdown(data, DefaultWorstFiles)
+	path := filepath.Join(t.TempDir(), "out", "summary.md")
+
+	tests := []struct {
+		name     string
+		append   bool
+		expected string
+	}{
+		{"replace", false, summary},
+		{"replace again", false, summary},
+		{"append", true, summary + summary},
+	}
+	for _, tt := range tests {
+		if err := NewMarkdownReportWriter(DefaultWorstFiles, tt.append).Write(data, path); err != nil {
+			t.Fatalf("%s: Write: %v", tt.name, err)
+		}
+		written, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(written) != tt.expected {
+			t.Errorf("%s: wrote %q, expected %q", tt.name, written, tt.expected)
+		}
+	}
+}
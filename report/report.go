@@ -3,12 +3,13 @@ package report
 import (
 	"codeleft-cli/filter" // Assuming this path is correct
 	"fmt"
+	"os"
 	"log"
 )
 
 // GenerateReport orchestrates the report generation process.
 // It depends on abstractions (ReportWriter) and coordinates different components.
-func GenerateReport(gradeDetails []filter.GradeDetails, outputPath string, thresholdGrade string, writer ReportWriter) error {
+func GenerateReport(gradeDetails []filter.GradeDetails, outputPath string, thresholdGrade string, weigher filter.CoverageWeigher, writer ReportWriter) error {
 	if len(gradeDetails) == 0 {
 		log.Println("Warning: No grade details provided to generate report.")
 		// Handle appropriately - maybe write an empty report or return specific error
@@ -22,7 +23,7 @@ func GenerateReport(gradeDetails []filter.GradeDetails, outputPath string, thres
 	rootNodes := builder.BuildReportTree(groupedDetails)
 
 	// 2. Calculate coverages and aggregate stats
-	calculator := NewCoverageCalculator(thresholdGrade)
+	calculator := NewCoverageCalculator(weigher)
 	stats := NewGlobalStats()
 	for _, node := range rootNodes {
 		calculator.CalculateNodeCoverages(node, stats) // Modifies nodes and stats
@@ -32,7 +33,7 @@ func GenerateReport(gradeDetails []filter.GradeDetails, outputPath string, thres
 	sortReportNodes(rootNodes)
 
     // 4. Calculate final overall averages
-    overallAverages, totalAverage, allTools := calculator.CalculateOverallAverages(stats)
+    overallAverages, totalAverage, allTools := calculator.CalculateOverallAverages(stats, gradeDetails)
 
 	// 5. Prepare data for the view
 	viewData := ReportViewData{
@@ -49,6 +50,6 @@ func GenerateReport(gradeDetails []filter.GradeDetails, outputPath string, thres
 		return fmt.Errorf("failed to write report: %w", err)
 	}
 
-	fmt.Printf("Successfully generated repository report: %s\n", outputPath)
+	fmt.Fprintf(os.Stderr, "Successfully generated repository report: %s\n", outputPath)
 	return nil
 }
\ No newline at end of file
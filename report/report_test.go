@@ -0,0 +1,56 @@
+package report
+
+import (
+	"codeleft-cli/assessment"
+	"codeleft-cli/filter"
+	"math"
+	"testing"
+)
+
+// capturingWriter is a ReportWriter that keeps the view data instead of writing it.
+type capturingWriter struct {
+	data ReportViewData
+}
+
+func (w *capturingWriter) Write(data ReportViewData, outputPath string) error {
+	w.data = data
+	return nil
+}
+
+func TestGenerateReportAveragesMatchCoverageGate(t *testing.T) {
+	// a.go has three tools and b.go one, so averaging per file would differ from
+	// averaging per file/tool pair.
+	details := []filter.GradeDetails{
+		{FileName: "cli/a.go", Tool: "SOLID", Grade: "A", Coverage: 100},
+		{FileName: "cli/a.go", Tool: "OWASP-TOP-10", Grade: "B", Coverage: 90},
+		{FileName: "cli/a.go", Tool: "Complexity", Grade: "C", Coverage: 80},
+		{FileName: "filter/b.go", Tool: "SOLID", Grade: "F", Coverage: 10},
+		{FileName: "filter/c.go", Tool: "SOLID", Unassessed: true},
+	}
+	weighers := map[string]filter.CoverageWeigher{
+		"uniform": filter.NewUniformWeigher(),
+		"tools":   filter.NewToolWeigher(map[string]float64{"SOLID": 3}),
+		"paths":   filter.NewPathWeigher([]filter.PathWeight{{Pattern: filter.ParseConfigIgnorePatterns([]string{"filter/"})[0], Weight: 2}}),
+	}
+	for name, weigher := range weighers {
+		t.Run(name, func(t *testing.T) {
+			writer := &capturingWriter{}
+			if err := GenerateReport(details, "report.out", "B", weigher, writer); err != nil {
+				t.Fatalf("GenerateReport: %v", err)
+			}
+
+			gate := assessment.NewAverages(details, weigher)
+			if math.Abs(writer.data.TotalAverage-gate.Overall) > 1e-9 {
+				t.Errorf("report total %.4f, coverage gate %.4f", writer.data.TotalAverage, gate.Overall)
+			}
+			if len(writer.data.OverallAverages) != len(gate.Tools) {
+				t.Errorf("report tools %v, coverage gate tools %v", writer.data.OverallAverages, gate.Tools)
+			}
+			for tool, average := range gate.Tools {
+				if math.Abs(writer.data.OverallAverages[tool]-average) > 1e-9 {
+					t.Errorf("%s: report average %.4f, coverage gate %.4f", tool, writer.data.OverallAverages[tool], average)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultSARIFReportPath is where "report sarif" writes by default.
+const DefaultSARIFReportPath = "CodeLeft.sarif"
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// SarifLog is the root of a SARIF 2.1.0 document.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun holds the results of a single run of a tool.
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+// SarifTool describes the tool that produced a run.
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+// SarifDriver names the tool and lists the rules its results refer to.
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+// SarifRule is one kind of finding: a review task of one CodeLeft tool.
+type SarifRule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	ShortDescription SarifMessage   `json:"shortDescription"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+// SarifResult is a single finding.
+type SarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SarifMessage      `json:"message"`
+	Locations           []SarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// SarifMessage is a plain-text message.
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SarifLocation points a result at a file and, when known, a range of lines.
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// SarifReport writes the unresolved code review tasks of the latest grades as a SARIF log,
+// which GitHub code scanning and IDEs show inline.
+type SarifReport struct {
+	OutputPath  string
+	ToolVersion string
+}
+
+func NewSarifReport(outputPath string, toolVersion string) *SarifReport {
+	return &SarifReport{OutputPath: outputPath, ToolVersion: toolVersion}
+}
+
+// GenerateSarif writes the SARIF log for the histories to OutputPath.
+func (s *SarifReport) GenerateSarif(histories filter.Histories) error {
+	log := BuildSarifLog(histories, s.ToolVersion)
+
+	if err := os.MkdirAll(filepath.Dir(s.OutputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory '%s': %w", filepath.Dir(s.OutputPath), err)
+	}
+	encoded, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+	if err := os.WriteFile(s.OutputPath, append(encoded, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write SARIF output file '%s': %w", s.OutputPath, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully generated SARIF report with %d result(s): %s\n", len(log.Runs[0].Results), s.OutputPath)
+	return nil
+}
+
+// BuildSarifLog turns every unresolved review task into a result. Rules are identified by
+// tool and task, e.g. "SOLID/singleResponsibility".
+func BuildSarifLog(histories filter.Histories, toolVersion string) SarifLog {
+	rules := []SarifRule{}
+	ruleIndices := make(map[string]int)
+	results := []SarifResult{}
+
+	sorted := append(filter.Histories{}, histories...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].FilePath != sorted[j].FilePath {
+			return sorted[i].FilePath < sorted[j].FilePath
+		}
+		return sorted[i].AssessingTool < sorted[j].AssessingTool
+	})
+
+	occurrences := make(map[string]int)
+	for _, history := range sorted {
+		for _, task := range history.ReviewTasks() {
+			if task.Resolved() || task.TitleTask == "" {
+				continue
+			}
+
+			ruleID := history.AssessingTool + "/" + task.TitleTask
+			index, known := ruleIndices[ruleID]
+			if !known {
+				index = len(rules)
+				ruleIndices[ruleID] = index
+				rules = append(rules, SarifRule{
+					ID:               ruleID,
+					Name:             task.TitleTask,
+					ShortDescription: SarifMessage{Text: task.TitleTask + " (" + history.AssessingTool + ")"},
+					Properties:       map[string]any{"tags": []string{history.AssessingTool}},
+				})
+			}
+
+			results = append(results, SarifResult{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     sarifLevel(task.Severity),
+				Message:   SarifMessage{Text: task.TitleTask},
+				Locations: []SarifLocation{{
+					PhysicalLocation: SarifPhysicalLocation{
+						ArtifactLocation: SarifArtifactLocation{URI: history.FilePath, URIBaseID: "%SRCROOT%"},
+						Region:           taskRegion(task, history.CodeDiff),
+					},
+				}},
+				PartialFingerprints: map[string]string{"codeLeftTask/v1": uniqueFingerprint(taskFingerprint(history, task), occurrences)},
+				Properties: map[string]any{
+					"grade":    history.Grade,
+					"severity": task.Severity,
+					"stale":    history.Stale,
+				},
+			})
+		}
+	}
+
+	return SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{{
+			Tool: SarifTool{Driver: SarifDriver{
+				Name:           "CodeLeft",
+				InformationURI: "https://github.com/henrylamb/codeleft-cli",
+				Version:        toolVersion,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// sarifLevel maps a review task severity onto a SARIF level.
+func sarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// taskRegion locates the task by its own line range, else by the lines its codeDiff changed.
+// Without either the result points at the file as a whole.
+func taskRegion(task filter.ReviewTask, diff filter.CodeDiff) *SarifRegion {
+	start, end := task.LineStart, task.LineEnd
+	if start <= 0 {
+		start, end = diffRange(diff)
+	}
+	if start <= 0 {
+		return nil
+	}
+	if end < start {
+		end = start
+	}
+	return &SarifRegion{StartLine: start, EndLine: end}
+}
+
+// diffRange spans the changes of the codeDiff, from the first changed line to the last.
+// It is 0, 0 when no change has a line number.
+func diffRange(diff filter.CodeDiff) (start int, end int) {
+	for _, change := range diff.Changes {
+		if change.Start <= 0 {
+			continue
+		}
+		changeEnd := change.End
+		if changeEnd < change.Start {
+			changeEnd = change.Start
+		}
+		if start == 0 || change.Start < start {
+			start = change.Start
+		}
+		if changeEnd > end {
+			end = changeEnd
+		}
+	}
+	return start, end
+}
+
+// taskFingerprint identifies a task independently of line numbers, so that code scanning can
+// follow it while the file changes.
+func taskFingerprint(history filter.History, task filter.ReviewTask) string {
+	digest := sha256.Sum256([]byte(history.FilePath + "|" + history.AssessingTool + "|" + task.TitleTask))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,149 @@
+package report
+
+import (
+	"codeleft-cli/filter"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// reviewedHistory is a history record whose detailed review has the tasks.
+func reviewedHistory(path string, tool string, grade string, tasks ...map[string]any) filter.History {
+	taskList := []any{}
+	for _, task := range tasks {
+		taskList = append(taskList, task)
+	}
+	return filter.History{
+		FilePath:      path,
+		AssessingTool: tool,
+		Grade:         grade,
+		CodeReview:    map[string]any{"detailedReview": map[string]any{"codeReviewTitle": "Review of " + path, "tasks": taskList}},
+	}
+}
+
+func TestBuildSarifLog(t *testing.T) {
+	histories := filter.Histories{
+		reviewedHistory("b.go", "SOLID", "C",
+			map[string]any{"titleTask": "Split handler", "severity": "high", "lineStart": 10.0, "lineEnd": 12.0},
+			map[string]any{"titleTask": "Split handler", "severity": "medium", "violatingCode": "```go\nfunc handle() {}\n```"},
+			map[string]any{"titleTask": "Done already", "done": true},
+			map[string]any{"titleTask": "Completed", "status": "Completed"},
+		),
+		reviewedHistory("a.go", "SOLID", "B", map[string]any{"titleTask": "Split handler", "severity": "low"}),
+		reviewedHistory("a.go", "Clean-Code", "B"),
+	}
+
+	log := BuildSarifLog(histories, "1.2.3")
+	run := log.Runs[0]
+	if log.Version != sarifVersion || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("version %q, driver version %q", log.Version, run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "SOLID/Split handler" {
+		t.Fatalf("rules = %+v, expected the single SOLID/Split handler rule", run.Tool.Driver.Rules)
+	}
+
+	expected := []struct {
+		uri   string
+		level string
+		lines int // Start line, 0 without a region
+	}{
+		{"a.go", "note", 0},
+		{"b.go", "error", 10},
+		{"b.go", "warning", 0},
+	}
+	if len(run.Results) != len(expected) {
+		t.Fatalf("%d results, expected %d: resolved tasks and tools without tasks have none", len(run.Results), len(expected))
+	}
+	fingerprints := make(map[string]bool)
+	for i, result := range run.Results {
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != expected[i].uri || result.Level != expected[i].level {
+			t.Errorf("result %d at %s with level %s, expected %s and %s", i, location.ArtifactLocation.URI, result.Level, expected[i].uri, expected[i].level)
+		}
+		startLine := 0
+		if location.Region != nil {
+			startLine = location.Region.StartLine
+		}
+		if startLine != expected[i].lines {
+			t.Errorf("result %d starts at line %d, expected %d", i, startLine, expected[i].lines)
+		}
+		if result.Message.Text != "Split handler" {
+			t.Errorf("result %d has message %q, expected the task title", i, result.Message.Text)
+		}
+		if result.RuleIndex != 0 {
+			t.Errorf("result %d has rule index %d, expected 0", i, result.RuleIndex)
+		}
+
+		fingerprint := result.PartialFingerprints["codeLeftTask/v1"]
+		if fingerprints[fingerprint] {
+			t.Errorf("result %d repeats fingerprint %s", i, fingerprint)
+		}
+		fingerprints[fingerprint] = true
+	}
+}
+
+func TestBuildSarifLogFingerprintsAreStable(t *testing.T) {
+	history := reviewedHistory("a.go", "SOLID", "B", map[string]any{"titleTask": "Split handler", "lineStart": 3.0})
+	moved := reviewedHistory("a.go", "SOLID", "C", map[string]any{"titleTask": "Split handler", "lineStart": 30.0})
+
+	first := BuildSarifLog(filter.Histories{history}, "").Runs[0].Results[0].PartialFingerprints
+	second := BuildSarifLog(filter.Histories{moved}, "").Runs[0].Results[0].PartialFingerprints
+	if first["codeLeftTask/v1"] != second["codeLeftTask/v1"] {
+		t.Errorf("fingerprint changed with the grade and line: %s, then %s", first["codeLeftTask/v1"], second["codeLeftTask/v1"])
+	}
+}
+
+func TestTaskRegion(t *testing.T) {
+	diff := filter.CodeDiff{Changes: []filter.CodeChange{{Start: 40, End: 44, OldCode: "return nil"}, {Start: 12, End: 10}, {OldCode: "x := 1"}}}
+
+	tests := []struct {
+		name  string
+		task  filter.ReviewTask
+		diff  filter.CodeDiff
+		start int
+		end   int
+	}{
+		{"own line range", filter.ReviewTask{LineStart: 5, LineEnd: 7}, diff, 5, 7},
+		{"end before start", filter.ReviewTask{LineStart: 5, LineEnd: 2}, diff, 5, 5},
+		{"own line range without a diff", filter.ReviewTask{LineStart: 5}, filter.CodeDiff{}, 5, 5},
+		{"diff range", filter.ReviewTask{ViolatingCode: "```go\n    return   nil\n```"}, diff, 12, 44},
+		{"diff range whatever the code quoted", filter.ReviewTask{ViolatingCode: "Vague"}, diff, 12, 44},
+		{"no diff", filter.ReviewTask{ViolatingCode: "```go\n12: a := 1\n```"}, filter.CodeDiff{}, 0, 0},
+		{"diff without line numbers", filter.ReviewTask{}, filter.CodeDiff{Changes: []filter.CodeChange{{OldCode: "x := 1"}}}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := taskRegion(tt.task, tt.diff)
+			if tt.start == 0 {
+				if region != nil {
+					t.Errorf("region = %+v, expected the file as a whole", region)
+				}
+				return
+			}
+			if region == nil || region.StartLine != tt.start || region.EndLine != tt.end {
+				t.Errorf("region = %+v, expected lines %d-%d", region, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestSarifReportGenerateSarif(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "out", "CodeLeft.sarif")
+	histories := filter.Histories{reviewedHistory("a.go", "SOLID", "B", map[string]any{"titleTask": "Split handler"})}
+
+	if err := NewSarifReport(outputPath, "1.0.0").GenerateSarif(histories); err != nil {
+		t.Fatalf("GenerateSarif: %v", err)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("reading the SARIF log: %v", err)
+	}
+	var log SarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("decoding the SARIF log: %v", err)
+	}
+	if log.Schema != sarifSchema || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Errorf("log = %+v, expected one run with one result", log)
+	}
+}
@@ -8,6 +8,15 @@ import (
 	"strings"
 )
 
+// coverageColor is the colour of a coverage value in the HTML report and in badges.
+func coverageColor(coverage float64) string {
+	if coverage >= 100 { return "#76C474" }
+	if coverage >= 70 { return "#a0d080" }
+	if coverage >= 50 { return "#F0AB86" }
+	if coverage >= 30 { return "#f5be9f" }
+	return "#e04242"
+}
+
 // --- Template Functions (Removed getFileGrade and getFileTool) ---
 var templateFuncs = template.FuncMap{
 	"formatFloat": func(f float64) string {
@@ -23,13 +32,7 @@ var templateFuncs = template.FuncMap{
 		if coverage >= 30 { return "orange-low" }
 		return "red"
 	},
-	"getCoverageColor": func(coverage float64) string {
-		if coverage >= 100 { return "#76C474" }
-		if coverage >= 70 { return "#a0d080" }
-		if coverage >= 50 { return "#F0AB86" }
-		if coverage >= 30 { return "#f5be9f" }
-		return "#e04242"
-	},
+	"getCoverageColor": coverageColor,
 	"getToolAverage": func(averages map[string]float64, tool string) float64 {
 		if avg, ok := averages[tool]; ok {
 			return avg
@@ -55,6 +58,9 @@ var templateFuncs = template.FuncMap{
 	"split": func(s string, sep string) []string {
 		return strings.Split(s, sep)
 	},
+	"join": func(elems []string, sep string) string {
+		return strings.Join(elems, sep)
+	},
 	"dict": func(values ...interface{}) (map[string]interface{}, error) {
 		if len(values)%2 != 0 {
 			return nil, fmt.Errorf("dict requires an even number of arguments")
@@ -208,6 +214,24 @@ const repoReportTemplateHTML = `
         }
         .icon-folder { color: #58a6ff; } /* Lighter blue */
         .icon-file { color: #999999; } /* Adjusted grey */
+        .unassessed-badge {
+            margin-left: 6px;
+            padding: 0 5px;
+            border: 1px solid #e04242;
+            border-radius: 3px;
+            color: #e04242;
+            font-size: 0.75em;
+            text-transform: uppercase;
+        }
+        .stale-badge {
+            margin-left: 6px;
+            padding: 0 5px;
+            border: 1px solid #F0AB86;
+            border-radius: 3px;
+            color: #F0AB86;
+            font-size: 0.75em;
+            text-transform: uppercase;
+        }
         /* .grade-cell and .tool-cell classes can be removed from CSS if desired */
     </style>
 </head>
@@ -302,6 +326,8 @@ const repoReportTemplateHTML = `
                          <path fill-rule="evenodd" d="M3.75 1.5a.25.25 0 01.25-.25h8.5a.25.25 0 01.25.25v13.25a.25.25 0 01-.25.25H4a.25.25 0 01-.25-.25V1.5zM4 1.75v13h7.5V1.75H4z"></path>
                      </svg>
                     <span class="file-name">{{ $node.Name }}</span>
+                    {{ if $node.UnassessedTools }}<span class="unassessed-badge" title="Never graded by {{ join $node.UnassessedTools ", " }}">unassessed</span>{{ end }}
+                    {{ if $node.StaleTools }}<span class="stale-badge" title="Changed since graded by {{ join $node.StaleTools ", " }}">stale</span>{{ end }}
                 {{ end }}
             </td>
 
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"os"
@@ -39,4 +40,31 @@ func (w *HTMLReportWriter) Write(data ReportViewData, outputPath string) error {
 		return fmt.Errorf("failed to execute HTML template: %w", err)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// JSONReportWriter writes the report view data as an indented JSON document.
+type JSONReportWriter struct{}
+
+func NewJSONReportWriter() *JSONReportWriter {
+	return &JSONReportWriter{}
+}
+
+func (w *JSONReportWriter) Write(data ReportViewData, outputPath string) error {
+	outputDir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory '%s': %w", outputDir, err)
+	}
+
+	outputFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create JSON output file '%s': %w", outputPath, err)
+	}
+	defer outputFile.Close()
+
+	encoder := json.NewEncoder(outputFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package schema
+
+import (
+	_ "embed"
+)
+
+// configSchema is the JSON Schema for .codeLeft/config.json.
+//
+//go:embed config.schema.json
+var configSchema []byte
+
+// ConfigSchema returns the embedded JSON Schema for config.json.
+func ConfigSchema() []byte {
+	return configSchema
+}
+
+// NewConfigValidator creates a Validator for config.json documents.
+func NewConfigValidator() (Validator, error) {
+	return NewSchemaValidator(configSchema)
+}
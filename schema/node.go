@@ -0,0 +1,258 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// kind is the JSON type of a parsed node.
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindNumber
+	kindString
+	kindArray
+	kindObject
+)
+
+// member is a key/value pair of a JSON object, in source order.
+type member struct {
+	Key       string
+	KeyOffset int
+	Value     *node
+}
+
+// node is a parsed JSON value that remembers where it started in the document,
+// so that validation problems can point at a line and column.
+type node struct {
+	Kind    kind
+	Offset  int
+	Bool    bool
+	Number  float64
+	String  string
+	Items   []*node
+	Members []member
+}
+
+// SyntaxError is a malformed JSON document, with the byte offset of the problem.
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Message
+}
+
+// parser is a small recursive-descent JSON parser producing position-aware nodes.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+// parse parses a complete JSON document.
+func parse(data []byte) (*node, error) {
+	p := &parser{data: data}
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.data) {
+		return nil, p.errorf("unexpected %q after the top-level value", p.data[p.pos])
+	}
+	return value, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Offset: p.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) value() (*node, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of JSON input")
+	}
+
+	start := p.pos
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"':
+		s, err := p.string()
+		if err != nil {
+			return nil, err
+		}
+		return &node{Kind: kindString, Offset: start, String: s}, nil
+	case c == 't':
+		return p.literal("true", &node{Kind: kindBool, Offset: start, Bool: true})
+	case c == 'f':
+		return p.literal("false", &node{Kind: kindBool, Offset: start})
+	case c == 'n':
+		return p.literal("null", &node{Kind: kindNull, Offset: start})
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *parser) literal(text string, result *node) (*node, error) {
+	if len(p.data)-p.pos < len(text) || string(p.data[p.pos:p.pos+len(text)]) != text {
+		return nil, p.errorf("invalid literal, expected %s", text)
+	}
+	p.pos += len(text)
+	return result, nil
+}
+
+func (p *parser) number() (*node, error) {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	number, err := strconv.ParseFloat(string(p.data[start:p.pos]), 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", p.data[start:p.pos])
+	}
+	return &node{Kind: kindNumber, Offset: start, Number: number}, nil
+}
+
+// string scans a quoted string and lets encoding/json handle the escapes.
+func (p *parser) string() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal(p.data[start:p.pos], &s); err != nil {
+				p.pos = start
+				return "", p.errorf("invalid string: %v", err)
+			}
+			return s, nil
+		default:
+			p.pos++
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) array() (*node, error) {
+	result := &node{Kind: kindArray, Offset: p.pos, Items: []*node{}}
+	p.pos++ // [
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == ']' {
+		p.pos++
+		return result, nil
+	}
+
+	for {
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, item)
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unexpected end of JSON input, expected , or ]")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return result, nil
+		default:
+			return nil, p.errorf("unexpected %q, expected , or ]", p.data[p.pos])
+		}
+	}
+}
+
+func (p *parser) object() (*node, error) {
+	result := &node{Kind: kindObject, Offset: p.pos, Members: []member{}}
+	p.pos++ // {
+	p.skipSpace()
+	if p.pos < len(p.data) && p.data[p.pos] == '}' {
+		p.pos++
+		return result, nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+			return nil, p.errorf("expected a quoted object key")
+		}
+		keyOffset := p.pos
+		key, err := p.string()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] != ':' {
+			return nil, p.errorf("expected : after object key %q", key)
+		}
+		p.pos++
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		result.Members = append(result.Members, member{Key: key, KeyOffset: keyOffset, Value: value})
+
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, p.errorf("unexpected end of JSON input, expected , or }")
+		}
+		switch p.data[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return result, nil
+		default:
+			return nil, p.errorf("unexpected %q, expected , or }", p.data[p.pos])
+		}
+	}
+}
+
+// lineColumn converts a byte offset into a 1-based line and column.
+func lineColumn(data []byte, offset int) (line int, column int) {
+	line, column = 1, 1
+	for i := 0; i < offset && i < len(data); i++ {
+		if data[i] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
@@ -0,0 +1,298 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Problem is a single place where a document does not match its schema.
+type Problem struct {
+	Path    string // JSON path of the offending value, e.g. $.ignore.files[0]
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d, column %d: %s: %s", p.Line, p.Column, p.Path, p.Message)
+}
+
+// Validator checks JSON documents against a JSON Schema.
+type Validator interface {
+	Validate(document []byte) []Problem
+}
+
+// SchemaValidator implements the subset of JSON Schema used by the CLI's schemas:
+// type, enum, pattern, properties, patternProperties, additionalProperties, required, items,
+// minLength, minimum, maximum and local $ref into $defs.
+type SchemaValidator struct {
+	root map[string]any
+}
+
+// NewSchemaValidator parses a JSON Schema document.
+func NewSchemaValidator(schemaDocument []byte) (*SchemaValidator, error) {
+	var root map[string]any
+	if err := json.Unmarshal(schemaDocument, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema: %w", err)
+	}
+	return &SchemaValidator{root: root}, nil
+}
+
+// Validate returns every problem found in the document, ordered by position.
+// A document that is not valid JSON yields a single problem at the syntax error.
+func (v *SchemaValidator) Validate(document []byte) []Problem {
+	tree, err := parse(document)
+	if err != nil {
+		offset := 0
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = syntaxErr.Offset
+		}
+		line, column := lineColumn(document, offset)
+		return []Problem{{Path: "$", Line: line, Column: column, Message: err.Error()}}
+	}
+
+	run := &validation{validator: v, document: document}
+	run.check(tree, v.root, "$")
+	sort.SliceStable(run.problems, func(i, j int) bool {
+		if run.problems[i].Line != run.problems[j].Line {
+			return run.problems[i].Line < run.problems[j].Line
+		}
+		return run.problems[i].Column < run.problems[j].Column
+	})
+	return run.problems
+}
+
+// validation collects the problems of one Validate call.
+type validation struct {
+	validator *SchemaValidator
+	document  []byte
+	problems  []Problem
+}
+
+func (r *validation) report(offset int, path string, format string, args ...any) {
+	line, column := lineColumn(r.document, offset)
+	r.problems = append(r.problems, Problem{Path: path, Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve follows a local "$ref" such as "#/$defs/toggle".
+func (r *validation) resolve(schema map[string]any) map[string]any {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	current := any(r.validator.root)
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		current = object[part]
+	}
+	resolved, ok := current.(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+	return r.resolve(resolved)
+}
+
+// check validates value against schema, recursing into objects and arrays.
+func (r *validation) check(value *node, schema map[string]any, path string) {
+	schema = r.resolve(schema)
+
+	if expected, ok := schema["type"]; ok && !matchesType(value, expected) {
+		r.report(value.Offset, path, "expected %s, got %s", describeType(expected), kindName(value.Kind))
+		return
+	}
+
+	if options, ok := schema["enum"].([]any); ok && !matchesEnum(value, options) {
+		r.report(value.Offset, path, "must be one of %s", describeEnum(options))
+	}
+
+	switch value.Kind {
+	case kindString:
+		if minLength, ok := schema["minLength"].(float64); ok && float64(len([]rune(value.String))) < minLength {
+			r.report(value.Offset, path, "must be at least %d characters long", int(minLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if matched, err := regexp.MatchString(pattern, value.String); err == nil && !matched {
+				r.report(value.Offset, path, "must match %s%s", pattern, describe(schema))
+			}
+		}
+	case kindNumber:
+		if minimum, ok := schema["minimum"].(float64); ok && value.Number < minimum {
+			r.report(value.Offset, path, "must be at least %v", minimum)
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && value.Number > maximum {
+			r.report(value.Offset, path, "must be at most %v", maximum)
+		}
+	case kindArray:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value.Items {
+				r.check(item, items, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case kindObject:
+		r.checkObject(value, schema, path)
+	}
+}
+
+func (r *validation) checkObject(value *node, schema map[string]any, path string) {
+	properties, _ := schema["properties"].(map[string]any)
+	patternProperties, _ := schema["patternProperties"].(map[string]any)
+
+	present := make(map[string]bool)
+	for _, m := range value.Members {
+		memberPath := path + "." + m.Key
+
+		if propertySchema, ok := properties[m.Key].(map[string]any); ok {
+			present[m.Key] = true
+			r.check(m.Value, propertySchema, memberPath)
+			continue
+		}
+
+		matchedPattern := false
+		for pattern, patternSchema := range patternProperties {
+			if matched, err := regexp.MatchString(pattern, m.Key); err == nil && matched {
+				matchedPattern = true
+				if s, ok := patternSchema.(map[string]any); ok {
+					r.check(m.Value, s, memberPath)
+				}
+			}
+		}
+		if matchedPattern {
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				r.report(m.KeyOffset, memberPath, "unknown property %q%s", m.Key, suggestion(m.Key, properties))
+			}
+		case map[string]any:
+			r.check(m.Value, additional, memberPath)
+		}
+	}
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok && !present[key] {
+				r.report(value.Offset, path, "missing required property %q", key)
+			}
+		}
+	}
+}
+
+// suggestion proposes the known property a misspelled key was probably meant to be.
+func suggestion(key string, properties map[string]any) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic when two properties differ only in case
+	for _, name := range names {
+		if strings.EqualFold(name, key) {
+			return fmt.Sprintf(" (did you mean %q?)", name)
+		}
+	}
+	return ""
+}
+
+// describe quotes a schema's description for a problem message.
+func describe(schema map[string]any) string {
+	if description, ok := schema["description"].(string); ok {
+		return " (" + description + ")"
+	}
+	return ""
+}
+
+func matchesType(value *node, expected any) bool {
+	switch t := expected.(type) {
+	case string:
+		return matchesTypeName(value, t)
+	case []any:
+		for _, candidate := range t {
+			if name, ok := candidate.(string); ok && matchesTypeName(value, name) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTypeName(value *node, name string) bool {
+	switch name {
+	case "integer":
+		return value.Kind == kindNumber && value.Number == float64(int64(value.Number))
+	case "number":
+		return value.Kind == kindNumber
+	default:
+		return kindName(value.Kind) == name
+	}
+}
+
+func matchesEnum(value *node, options []any) bool {
+	for _, option := range options {
+		switch o := option.(type) {
+		case string:
+			if value.Kind == kindString && value.String == o {
+				return true
+			}
+		case float64:
+			if value.Kind == kindNumber && value.Number == o {
+				return true
+			}
+		case bool:
+			if value.Kind == kindBool && value.Bool == o {
+				return true
+			}
+		case nil:
+			if value.Kind == kindNull {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func kindName(k kind) string {
+	switch k {
+	case kindBool:
+		return "boolean"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindArray:
+		return "array"
+	case kindObject:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+func describeType(expected any) string {
+	if types, ok := expected.([]any); ok {
+		names := make([]string, 0, len(types))
+		for _, t := range types {
+			names = append(names, fmt.Sprint(t))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(expected)
+}
+
+func describeEnum(options []any) string {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		encoded, _ := json.Marshal(option)
+		values = append(values, string(encoded))
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigValidatorValidate(t *testing.T) {
+	validator, err := NewConfigValidator()
+	if err != nil {
+		t.Fatalf("NewConfigValidator: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		config   string
+		problems []string // Substrings of the expected messages, in order
+	}{
+		{"valid", `{"threshold": "B", "ignore": {"patterns": ["gen/"]}}`, nil},
+		{"grades are case-insensitive", `{"threshold": "a-", "thresholds": [{"tool": "SOLID", "grade": "c+"}]}`, nil},
+		{"unknown grade", `{"threshold": "Q"}`, []string{"$.threshold: must match"}},
+		{"key differing in case", `{"Ignore": {"folders": ["vendor"]}}`, []string{`$.Ignore: unknown property "Ignore" (did you mean "ignore"?)`}},
+		{"nested key differing in case", `{"security": {"OWASP": true}}`, []string{`did you mean "owasp"?`}},
+		{"misspelled key", `{"ignroe": {}}`, []string{`unknown property "ignroe"`}},
+		{"annotations", `{"$comment": "kept", "security": {"$comment": "kept"}}`, nil},
+		{"wrong type", `{"security": {"owasp": "yes"}}`, []string{"$.security.owasp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := validator.Validate([]byte(tt.config))
+			if len(problems) != len(tt.problems) {
+				t.Fatalf("problems = %v, expected %d", problems, len(tt.problems))
+			}
+			for i, problem := range problems {
+				if text := problem.Path + ": " + problem.Message; !strings.Contains(text, tt.problems[i]) {
+					t.Errorf("problem %d = %q, expected it to contain %q", i, text, tt.problems[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConfigValidatorReportsPosition(t *testing.T) {
+	validator, err := NewConfigValidator()
+	if err != nil {
+		t.Fatalf("NewConfigValidator: %v", err)
+	}
+	problems := validator.Validate([]byte("{\n  \"threshold\": \"B\",\n  \"Ignore\": {}\n}"))
+	if len(problems) != 1 || problems[0].Line != 3 || problems[0].Column != 3 {
+		t.Errorf("problems = %+v, expected one at line 3, column 3", problems)
+	}
+}
@@ -0,0 +1,68 @@
+package types
+
+// languageProfile describes which tooling is worth enabling by default for a language.
+type languageProfile struct {
+	Owasp bool // The language is commonly used for networked services
+	Cwe   bool // The language is exposed to memory-safety weaknesses
+	Solid bool // The language is commonly written in an object-oriented style
+}
+
+// languageProfiles is keyed by the language names reported by read.LanguageDetector.
+var languageProfiles = map[string]languageProfile{
+	"C":          {Cwe: true},
+	"C++":        {Cwe: true, Solid: true},
+	"C#":         {Owasp: true, Solid: true},
+	"Go":         {Owasp: true, Solid: true},
+	"Java":       {Owasp: true, Solid: true},
+	"JavaScript": {Owasp: true},
+	"Kotlin":     {Owasp: true, Solid: true},
+	"PHP":        {Owasp: true, Solid: true},
+	"Python":     {Owasp: true, Solid: true},
+	"Ruby":       {Owasp: true, Solid: true},
+	"Rust":       {Owasp: true},
+	"Scala":      {Owasp: true, Solid: true},
+	"Swift":      {Solid: true},
+	"TypeScript": {Owasp: true, Solid: true},
+}
+
+// NewDefaultConfig returns the configuration written for a freshly initialised project.
+func NewDefaultConfig() *Config {
+	config := &Config{Threshold: "A-"}
+	config.Security.Owasp = true
+	config.Quality.Solid = true
+	config.Quality.Complexity = true
+	config.Ignore.Files = []File{}
+	config.Ignore.Folders = []string{}
+	config.Ignore.Patterns = []string{}
+	config.Sources.Extensions = []string{}
+	config.Sources.Unassessed = "off"
+	return config
+}
+
+// NewDefaultConfigForLanguages returns a default configuration whose quality and security
+// toggles suit the given languages. Complexity is always enabled. Without any recognised
+// language it falls back to NewDefaultConfig.
+func NewDefaultConfigForLanguages(languages []string) *Config {
+	config := NewDefaultConfig()
+
+	recognised := false
+	profile := languageProfile{}
+	for _, language := range languages {
+		languageProfile, ok := languageProfiles[language]
+		if !ok {
+			continue
+		}
+		recognised = true
+		profile.Owasp = profile.Owasp || languageProfile.Owasp
+		profile.Cwe = profile.Cwe || languageProfile.Cwe
+		profile.Solid = profile.Solid || languageProfile.Solid
+	}
+	if !recognised {
+		return config
+	}
+
+	config.Security.Owasp = profile.Owasp
+	config.Security.CweTop25 = profile.Cwe
+	config.Quality.Solid = profile.Solid
+	return config
+}
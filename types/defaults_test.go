@@ -0,0 +1,29 @@
+package types
+
+import "testing"
+
+func TestNewDefaultConfigForLanguages(t *testing.T) {
+	tests := []struct {
+		name                   string
+		languages              []string
+		owasp, cweTop25, solid bool
+	}{
+		{"no languages", nil, true, false, true},
+		{"unrecognised language", []string{"COBOL"}, true, false, true},
+		{"Go", []string{"Go"}, true, false, true},
+		{"C", []string{"C"}, false, true, false},
+		{"Rust", []string{"Rust"}, true, false, false},
+		{"C and JavaScript", []string{"C", "JavaScript"}, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewDefaultConfigForLanguages(tt.languages)
+			if config.Security.Owasp != tt.owasp || config.Security.CweTop25 != tt.cweTop25 || config.Quality.Solid != tt.solid {
+				t.Errorf("owasp %v, cweTop25 %v, solid %v, expected %v, %v, %v", config.Security.Owasp, config.Security.CweTop25, config.Quality.Solid, tt.owasp, tt.cweTop25, tt.solid)
+			}
+			if !config.Quality.Complexity || config.Threshold != "A-" {
+				t.Errorf("complexity %v, threshold %q, expected complexity on and threshold A-", config.Quality.Complexity, config.Threshold)
+			}
+		})
+	}
+}
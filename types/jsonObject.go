@@ -0,0 +1,171 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// objectFields records how a JSON object looked when it was decoded, so that it can be
+// encoded again without dropping unknown keys or reordering the keys the user wrote.
+// Reporting unknown keys is left to the config schema (see package schema).
+type objectFields struct {
+	order   []string                   // Keys in source order
+	unknown map[string]json.RawMessage // Keys with no matching struct field, kept verbatim
+}
+
+// has reports whether the object had the key, in any case.
+func (f objectFields) has(key string) bool {
+	for _, k := range f.order {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFieldName returns the JSON key of an exported struct field, or "" if it is not encoded.
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldIndex finds the struct field for a JSON key, preferring an exact match and falling
+// back to a case-insensitive one as encoding/json does.
+func fieldIndex(t reflect.Type, key string) (index int, ok bool) {
+	fallback := -1
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonFieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		if name == key {
+			return i, true
+		}
+		if fallback < 0 && strings.EqualFold(name, key) {
+			fallback = i
+		}
+	}
+	return fallback, fallback >= 0
+}
+
+// decodeObject decodes a JSON object into the struct pointed to by v, key by key, and
+// records the key order and unknown keys in fields.
+func decodeObject(data []byte, v any, fields *objectFields) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %v", token)
+	}
+
+	target := reflect.ValueOf(v).Elem()
+	*fields = objectFields{unknown: make(map[string]json.RawMessage)}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		fields.order = append(fields.order, key)
+
+		index, ok := fieldIndex(target.Type(), key)
+		if !ok {
+			fields.unknown[key] = raw
+			continue
+		}
+		if err := json.Unmarshal(raw, target.Field(index).Addr().Interface()); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// encodeObject encodes the struct v as a JSON object. Keys recorded in fields are written
+// first, in their original order and spelling; known fields that were not in the source
+// are then added only if they hold a non-zero value.
+func encodeObject(v any, fields objectFields) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	valueType := value.Type()
+
+	var buf bytes.Buffer
+	written := make(map[int]bool)
+	first := true
+	writeMember := func(key string, encoded []byte) error {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encoded)
+		return nil
+	}
+
+	buf.WriteByte('{')
+	for _, key := range fields.order {
+		if raw, ok := fields.unknown[key]; ok {
+			if err := writeMember(key, raw); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		index, ok := fieldIndex(valueType, key)
+		if !ok || written[index] {
+			continue
+		}
+		encoded, err := json.Marshal(value.Field(index).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if err := writeMember(key, encoded); err != nil {
+			return nil, err
+		}
+		written[index] = true
+	}
+
+	for i := 0; i < valueType.NumField(); i++ {
+		name := jsonFieldName(valueType.Field(i))
+		if name == "" || written[i] || value.Field(i).IsZero() {
+			continue
+		}
+		encoded, err := json.Marshal(value.Field(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := writeMember(name, encoded); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
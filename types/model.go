@@ -1,30 +1,375 @@
 package types
 
-// Config represents the structure of the config.json file.
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Config represents the structure of the config.json file, as written by the IDE extension.
+// Keys the CLI does not model are kept, so a decoded Config encodes back without losing them.
 type Config struct {
-	Threshold string `json:"threshold"`
-	Security  struct {
-		Owasp bool `json:"owasp"`
-		Cwe  bool `json:"cwe"`
-	} `json:"security"`
-	Quality struct {
-		Solid     bool `json:"solid"`
-		PrReady   bool `json:"prReady"`
-		CleanCode bool `json:"cleanCode"`
-		Complexity bool `json:"complexity"`
-		ComplexityPro bool `json:"complexityPro"`
-	} `json:"quality"`
-	SafetyCritical struct {
-		MisraCpp bool `json:"misraCpp"`
-	} `json:"safetyCritical"`
-	Ignore struct {
-		Files   []File   `json:"files"`
-		Folders []string `json:"folders"`
-	} `json:"ignore"`
+	Threshold      string               `json:"threshold"`
+	Security       SecurityConfig       `json:"security"`
+	Quality        QualityConfig        `json:"quality"`
+	SafetyCritical SafetyCriticalConfig `json:"safetyCritical"`
+	Testing        TestingConfig        `json:"testing"`
+	CodeReviewType CodeReviewTypeConfig `json:"codeReviewType"`
+	QualitySentry  QualitySentryConfig  `json:"qualitySentry"`
+	TestGeneration TestGenerationConfig `json:"testGeneration"`
+	Ignore         IgnoreConfig         `json:"ignore"`
+	Sources        SourcesConfig        `json:"sources"`
+	GradeScales    GradeScalesConfig    `json:"gradeScales"`
+	Coverage       CoverageConfig       `json:"coverage"`
+	Thresholds     []ThresholdRule      `json:"thresholds"`
+	Weighting      WeightingConfig      `json:"weighting"`
+	fields         objectFields
+}
+
+// SecurityConfig toggles the security tooling.
+type SecurityConfig struct {
+	Owasp    bool `json:"owasp"`
+	CweTop25 bool `json:"cweTop25"`
+	fields   objectFields
+	cweAlias bool // CweTop25 was read from the deprecated cwe key
+}
+
+// LegacyCweKey is the name security.cweTop25 had before it was renamed. It is still read
+// when cweTop25 is absent.
+const LegacyCweKey = "cwe"
+
+// QualityConfig toggles the code quality tooling.
+type QualityConfig struct {
+	Solid         bool `json:"solid"`
+	PrReady       bool `json:"prReady"`
+	CleanCode     bool `json:"cleanCode"`
+	Complexity    bool `json:"complexity"`
+	ComplexityPro bool `json:"complexityPro"`
+	Testability   bool `json:"testability"`
+	fields        objectFields
+}
+
+// SafetyCriticalConfig toggles the safety-critical coding standards.
+type SafetyCriticalConfig struct {
+	MisraCpp bool `json:"misraCpp"`
+	fields   objectFields
+}
+
+// TestingConfig toggles the test assessments.
+type TestingConfig struct {
+	FunctionalCoverage bool `json:"functionalCoverage"`
+	fields             objectFields
+}
+
+// CodeReviewTypeConfig selects the kind of code review the IDE extension runs.
+type CodeReviewTypeConfig struct {
+	Pro    bool `json:"pro"`
+	fields objectFields
+}
+
+// QualitySentryConfig configures the IDE extension's quality sentry.
+type QualitySentryConfig struct {
+	Tdd    bool `json:"tdd"`
+	fields objectFields
+}
+
+// TestGenerationConfig configures test generation in the IDE extension.
+type TestGenerationConfig struct {
+	Active   bool   `json:"active"`
+	Override bool   `json:"override"`
+	Language string `json:"language"`
+	TestType string `json:"testType"`
+	Library  string `json:"library"`
+	fields   objectFields
+}
+
+// IgnoreConfig lists the files, folders and gitignore-style patterns excluded from grades.
+type IgnoreConfig struct {
+	Files    []File   `json:"files"`
+	Folders  []string `json:"folders"`
+	Patterns []string `json:"patterns"`
+	fields   objectFields
+}
+
+// SourcesConfig describes which files in the repository are source code that should be
+// graded, and how source files without a grade are treated.
+type SourcesConfig struct {
+	Extensions []string `json:"extensions"` // e.g. ".go"; empty means every supported language
+	Unassessed string   `json:"unassessed"` // off, zero or fail
+	fields     objectFields
+}
+
+// GradeScalesConfig declares custom grade scales and the tools that record their grades on
+// them. Tools without an entry use the built-in letter scale.
+type GradeScalesConfig struct {
+	Scales map[string]GradeScaleConfig `json:"scales"` // Keyed by scale name
+	Tools  map[string]string           `json:"tools"`  // Tool name to scale name
+	fields objectFields
+}
+
+// GradeScaleConfig is a custom grade scale: either ordered labels, lowest first, or numeric
+// ranges, each mapping the scores from its minimum upwards onto a built-in grade.
+type GradeScaleConfig struct {
+	Labels []string     `json:"labels"`
+	Ranges []GradeRange `json:"ranges"`
+	fields objectFields
+}
+
+// GradeRange maps numeric scores of at least Min onto a built-in grade.
+type GradeRange struct {
+	Min    float64 `json:"min"`
+	Grade  string  `json:"grade"`
+	fields objectFields
+}
+
+// CoverageConfig selects how a grade is turned into a coverage percentage. Strategy is
+// "steps" (the default), "linear" or "capped".
+type CoverageConfig struct {
+	Strategy   string `json:"strategy"`
+	Steps      []int  `json:"steps"`      // Coverage above the threshold, at it, then one grade below, two below, ...
+	LinearStep int    `json:"linearStep"` // Percentage points per grade for the linear strategy
+	fields     objectFields
+}
+
+// ThresholdRule overrides the threshold grade, percentage or both for a tool, for the files
+// matching a gitignore-style path pattern, or for a tool within those files.
+type ThresholdRule struct {
+	Tool    string `json:"tool"`
+	Path    string `json:"path"`
+	Grade   string `json:"grade"`
+	Percent int    `json:"percent"`
+	fields  objectFields
+}
+
+// WeightingConfig selects how grades are weighted when coverage is averaged. Strategy is
+// "uniform" (the default), "linesOfCode", "tools" or "paths".
+type WeightingConfig struct {
+	Strategy string             `json:"strategy"`
+	Tools    map[string]float64 `json:"tools"` // Weight per tool for the tools strategy
+	Paths    []PathWeight       `json:"paths"` // Weights for the paths strategy; the last matching path wins
+	fields   objectFields
+}
+
+// PathWeight is the weight of the files matching a gitignore-style path pattern.
+type PathWeight struct {
+	Path   string  `json:"path"`
+	Weight float64 `json:"weight"`
+	fields objectFields
 }
 
 // File represents a file to be ignored in the config.
 type File struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
-}
\ No newline at end of file
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	fields objectFields
+}
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	return decodeObject(data, (*plain)(c), &c.fields)
+}
+
+func (c Config) MarshalJSON() ([]byte, error) {
+	type plain Config
+	return encodeObject(plain(c), c.fields)
+}
+
+func (s *SecurityConfig) UnmarshalJSON(data []byte) error {
+	type plain SecurityConfig
+	if err := decodeObject(data, (*plain)(s), &s.fields); err != nil {
+		return err
+	}
+	raw, ok := s.fields.unknown[LegacyCweKey]
+	if !ok || s.fields.has("cweTop25") {
+		return nil
+	}
+	if err := json.Unmarshal(raw, &s.CweTop25); err != nil {
+		return fmt.Errorf("%s: %w", LegacyCweKey, err)
+	}
+	s.cweAlias = true
+	return nil
+}
+
+// MarshalJSON writes CweTop25 back under the deprecated cwe key when it was read from it.
+func (s SecurityConfig) MarshalJSON() ([]byte, error) {
+	type plain SecurityConfig
+	if !s.cweAlias {
+		return encodeObject(plain(s), s.fields)
+	}
+	encoded, err := json.Marshal(s.CweTop25)
+	if err != nil {
+		return nil, err
+	}
+	fields := s.fields
+	fields.unknown = make(map[string]json.RawMessage)
+	for key, raw := range s.fields.unknown {
+		fields.unknown[key] = raw
+	}
+	fields.unknown[LegacyCweKey] = encoded
+	s.CweTop25 = false
+	return encodeObject(plain(s), fields)
+}
+
+// UsesCweAlias reports whether CweTop25 was read from the deprecated cwe key.
+func (s SecurityConfig) UsesCweAlias() bool {
+	return s.cweAlias
+}
+
+func (q *QualityConfig) UnmarshalJSON(data []byte) error {
+	type plain QualityConfig
+	return decodeObject(data, (*plain)(q), &q.fields)
+}
+
+func (q QualityConfig) MarshalJSON() ([]byte, error) {
+	type plain QualityConfig
+	return encodeObject(plain(q), q.fields)
+}
+
+func (s *SafetyCriticalConfig) UnmarshalJSON(data []byte) error {
+	type plain SafetyCriticalConfig
+	return decodeObject(data, (*plain)(s), &s.fields)
+}
+
+func (s SafetyCriticalConfig) MarshalJSON() ([]byte, error) {
+	type plain SafetyCriticalConfig
+	return encodeObject(plain(s), s.fields)
+}
+
+func (t *TestingConfig) UnmarshalJSON(data []byte) error {
+	type plain TestingConfig
+	return decodeObject(data, (*plain)(t), &t.fields)
+}
+
+func (t TestingConfig) MarshalJSON() ([]byte, error) {
+	type plain TestingConfig
+	return encodeObject(plain(t), t.fields)
+}
+
+func (c *CodeReviewTypeConfig) UnmarshalJSON(data []byte) error {
+	type plain CodeReviewTypeConfig
+	return decodeObject(data, (*plain)(c), &c.fields)
+}
+
+func (c CodeReviewTypeConfig) MarshalJSON() ([]byte, error) {
+	type plain CodeReviewTypeConfig
+	return encodeObject(plain(c), c.fields)
+}
+
+func (q *QualitySentryConfig) UnmarshalJSON(data []byte) error {
+	type plain QualitySentryConfig
+	return decodeObject(data, (*plain)(q), &q.fields)
+}
+
+func (q QualitySentryConfig) MarshalJSON() ([]byte, error) {
+	type plain QualitySentryConfig
+	return encodeObject(plain(q), q.fields)
+}
+
+func (t *TestGenerationConfig) UnmarshalJSON(data []byte) error {
+	type plain TestGenerationConfig
+	return decodeObject(data, (*plain)(t), &t.fields)
+}
+
+func (t TestGenerationConfig) MarshalJSON() ([]byte, error) {
+	type plain TestGenerationConfig
+	return encodeObject(plain(t), t.fields)
+}
+
+func (i *IgnoreConfig) UnmarshalJSON(data []byte) error {
+	type plain IgnoreConfig
+	return decodeObject(data, (*plain)(i), &i.fields)
+}
+
+func (i IgnoreConfig) MarshalJSON() ([]byte, error) {
+	type plain IgnoreConfig
+	return encodeObject(plain(i), i.fields)
+}
+
+func (s *SourcesConfig) UnmarshalJSON(data []byte) error {
+	type plain SourcesConfig
+	return decodeObject(data, (*plain)(s), &s.fields)
+}
+
+func (s SourcesConfig) MarshalJSON() ([]byte, error) {
+	type plain SourcesConfig
+	return encodeObject(plain(s), s.fields)
+}
+
+func (g *GradeScalesConfig) UnmarshalJSON(data []byte) error {
+	type plain GradeScalesConfig
+	return decodeObject(data, (*plain)(g), &g.fields)
+}
+
+func (g GradeScalesConfig) MarshalJSON() ([]byte, error) {
+	type plain GradeScalesConfig
+	return encodeObject(plain(g), g.fields)
+}
+
+func (g *GradeScaleConfig) UnmarshalJSON(data []byte) error {
+	type plain GradeScaleConfig
+	return decodeObject(data, (*plain)(g), &g.fields)
+}
+
+func (g GradeScaleConfig) MarshalJSON() ([]byte, error) {
+	type plain GradeScaleConfig
+	return encodeObject(plain(g), g.fields)
+}
+
+func (g *GradeRange) UnmarshalJSON(data []byte) error {
+	type plain GradeRange
+	return decodeObject(data, (*plain)(g), &g.fields)
+}
+
+func (g GradeRange) MarshalJSON() ([]byte, error) {
+	type plain GradeRange
+	return encodeObject(plain(g), g.fields)
+}
+
+func (c *CoverageConfig) UnmarshalJSON(data []byte) error {
+	type plain CoverageConfig
+	return decodeObject(data, (*plain)(c), &c.fields)
+}
+
+func (c CoverageConfig) MarshalJSON() ([]byte, error) {
+	type plain CoverageConfig
+	return encodeObject(plain(c), c.fields)
+}
+
+func (t *ThresholdRule) UnmarshalJSON(data []byte) error {
+	type plain ThresholdRule
+	return decodeObject(data, (*plain)(t), &t.fields)
+}
+
+func (t ThresholdRule) MarshalJSON() ([]byte, error) {
+	type plain ThresholdRule
+	return encodeObject(plain(t), t.fields)
+}
+
+func (w *WeightingConfig) UnmarshalJSON(data []byte) error {
+	type plain WeightingConfig
+	return decodeObject(data, (*plain)(w), &w.fields)
+}
+
+func (w WeightingConfig) MarshalJSON() ([]byte, error) {
+	type plain WeightingConfig
+	return encodeObject(plain(w), w.fields)
+}
+
+func (p *PathWeight) UnmarshalJSON(data []byte) error {
+	type plain PathWeight
+	return decodeObject(data, (*plain)(p), &p.fields)
+}
+
+func (p PathWeight) MarshalJSON() ([]byte, error) {
+	type plain PathWeight
+	return encodeObject(plain(p), p.fields)
+}
+
+func (f *File) UnmarshalJSON(data []byte) error {
+	type plain File
+	return decodeObject(data, (*plain)(f), &f.fields)
+}
+
+func (f File) MarshalJSON() ([]byte, error) {
+	type plain File
+	return encodeObject(plain(f), f.fields)
+}
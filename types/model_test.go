@@ -0,0 +1,90 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConfigRoundTripKeepsUnknownKeys(t *testing.T) {
+	source := `{
+  "$comment": "kept",
+  "quality": {"complexity": true, "futureTool": {"level": 2}, "solid": false},
+  "threshold": "B",
+  "extensionVersion": "1.4.0",
+  "ignore": {"folders": ["vendor"], "globs": ["*.gen.go"]}
+}`
+	var config Config
+	if err := json.Unmarshal([]byte(source), &config); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if config.Threshold != "B" || !config.Quality.Complexity || len(config.Ignore.Folders) != 1 {
+		t.Fatalf("decoded %+v", config)
+	}
+
+	encoded, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(source)); err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != compact.String() {
+		t.Errorf("round trip changed config.json:\n got %s\nwant %s", encoded, compact.String())
+	}
+
+	// Changed values stay in place; known fields that were absent are added only when set.
+	config.Threshold = "A-"
+	config.Security.Owasp = true
+	encoded, err = json.Marshal(&config)
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	expected := strings.Replace(compact.String(), `"threshold":"B"`, `"threshold":"A-"`, 1)
+	expected = strings.TrimSuffix(expected, "}") + `,"security":{"owasp":true}}`
+	if string(encoded) != expected {
+		t.Errorf("encoding the changed config:\n got %s\nwant %s", encoded, expected)
+	}
+}
+
+func TestSecurityConfigCweAlias(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		cweTop25 bool
+		alias    bool
+		encoded  string // Empty when decoding fails
+	}{
+		{"current key", `{"cweTop25":true}`, true, false, `{"cweTop25":true}`},
+		{"deprecated key", `{"owasp":false,"cwe":true}`, true, true, `{"owasp":false,"cwe":true}`},
+		{"current key wins", `{"cwe":false,"cweTop25":true}`, true, false, `{"cwe":false,"cweTop25":true}`},
+		{"wrong type", `{"cwe":"yes"}`, false, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var security SecurityConfig
+			err := json.Unmarshal([]byte(tt.source), &security)
+			if tt.encoded == "" {
+				if err == nil {
+					t.Errorf("decoding %s succeeded, expected an error", tt.source)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decoding: %v", err)
+			}
+			if security.CweTop25 != tt.cweTop25 || security.UsesCweAlias() != tt.alias {
+				t.Errorf("cweTop25 %v, alias %v, expected %v and %v", security.CweTop25, security.UsesCweAlias(), tt.cweTop25, tt.alias)
+			}
+			encoded, err := json.Marshal(security)
+			if err != nil {
+				t.Fatalf("encoding: %v", err)
+			}
+			if string(encoded) != tt.encoded {
+				t.Errorf("encoded %s, expected %s", encoded, tt.encoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+// toolToggle pairs the name of an assessing tool with whether config.json enables it.
+type toolToggle struct {
+	Tool    string
+	Enabled bool
+}
+
+// EnabledTools returns the assessing tool names for every toggle enabled in the config,
+// spelled as they appear in history.ndjson.
+func (c *Config) EnabledTools() []string {
+	toggles := []toolToggle{
+		{Tool: "OWASP-TOP-10", Enabled: c.Security.Owasp},
+		{Tool: "CWE-TOP-25", Enabled: c.Security.CweTop25},
+		{Tool: "SOLID", Enabled: c.Quality.Solid},
+		{Tool: "Clean-Code", Enabled: c.Quality.CleanCode},
+		{Tool: "PR-Ready", Enabled: c.Quality.PrReady},
+		{Tool: "Complexity", Enabled: c.Quality.Complexity},
+		{Tool: "Complexity-Pro", Enabled: c.Quality.ComplexityPro},
+		{Tool: "Testability", Enabled: c.Quality.Testability},
+		{Tool: "MISRA-C++", Enabled: c.SafetyCritical.MisraCpp},
+		{Tool: "Functional-Coverage", Enabled: c.Testing.FunctionalCoverage},
+	}
+
+	tools := []string{}
+	for _, toggle := range toggles {
+		if toggle.Enabled {
+			tools = append(tools, toggle.Tool)
+		}
+	}
+	return tools
+}
@@ -0,0 +1,53 @@
+package write
+
+import (
+	"codeleft-cli/filter"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BaselineWriter writes the ratchet baseline.
+type BaselineWriter interface {
+	WriteBaseline(baseline *filter.Baseline) error
+}
+
+// JSONBaselineWriter writes baseline.json as indented JSON, so that it diffs well when it
+// is committed. The file is replaced atomically, like history.ndjson.
+type JSONBaselineWriter struct {
+	Path string
+}
+
+// NewBaselineWriter creates a BaselineWriter for the baseline.json file at path.
+func NewBaselineWriter(path string) BaselineWriter {
+	return &JSONBaselineWriter{Path: path}
+}
+
+func (w *JSONBaselineWriter) WriteBaseline(baseline *filter.Baseline) error {
+	encoded, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(w.Path), ".baseline-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary baseline file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(encoded, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary baseline file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set baseline permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), w.Path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", w.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package write
+
+import (
+	"codeleft-cli/read"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HistoryWriter rewrites history.ndjson from a set of records.
+type HistoryWriter interface {
+	WriteRecords(records []read.HistoryRecord) error
+}
+
+// NDJSONHistoryWriter writes records back as one raw JSON document per line.
+// The file is replaced atomically so an interrupted write never truncates history.
+type NDJSONHistoryWriter struct {
+	Path string
+}
+
+// NewHistoryWriter creates a HistoryWriter for the history.ndjson file at path.
+func NewHistoryWriter(path string) HistoryWriter {
+	return &NDJSONHistoryWriter{Path: path}
+}
+
+// WriteRecords writes the raw form of each record to a temporary file and renames it over Path.
+func (w *NDJSONHistoryWriter) WriteRecords(records []read.HistoryRecord) error {
+	tmp, err := os.CreateTemp(filepath.Dir(w.Path), ".history-*.ndjson")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	for _, record := range records {
+		if _, err := tmp.Write(append(record.Raw, '\n')); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write history record from line %d: %w", record.Line, err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary history file: %w", err)
+	}
+
+	if info, err := os.Stat(w.Path); err == nil {
+		if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to preserve history.ndjson permissions: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), w.Path); err != nil {
+		return fmt.Errorf("failed to replace history.ndjson: %w", err)
+	}
+	return nil
+}
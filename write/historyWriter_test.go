@@ -0,0 +1,81 @@
+package write
+
+import (
+	"codeleft-cli/read"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNDJSONHistoryWriterWriteRecords(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.ndjson")
+	if err := os.WriteFile(path, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Records are written as read, including spacing and keys the CLI does not know.
+	records := []read.HistoryRecord{
+		{Line: 1, Raw: []byte(`{"filePath": "a.go", "grade": "A", "reviewer": "kept"}`)},
+		{Line: 3, Raw: []byte(`{"filePath":"b.go","grade":"C"}`)},
+	}
+	if err := NewHistoryWriter(path).WriteRecords(records); err != nil {
+		t.Fatalf("WriteRecords: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := string(records[0].Raw) + "\n" + string(records[1].Raw) + "\n"; string(data) != expected {
+		t.Errorf("history.ndjson = %q, expected %q", data, expected)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("history.ndjson mode %v, expected the original 0600 to be kept", mode)
+	}
+	assertOnlyFiles(t, dir, "history.ndjson")
+}
+
+func TestNDJSONHistoryWriterKeepsHistoryOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	// A directory in place of history.ndjson cannot be replaced by the temporary file.
+	path := filepath.Join(dir, "history.ndjson")
+	if err := os.MkdirAll(filepath.Join(path, "entry"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewHistoryWriter(path).WriteRecords([]read.HistoryRecord{{Line: 1, Raw: []byte(`{}`)}})
+	if err == nil {
+		t.Fatalf("WriteRecords replaced a non-empty directory")
+	}
+	if _, err := os.Stat(filepath.Join(path, "entry")); err != nil {
+		t.Errorf("the original was changed: %v", err)
+	}
+	assertOnlyFiles(t, dir, "history.ndjson")
+
+	if err := NewHistoryWriter(filepath.Join(dir, "missing", "history.ndjson")).WriteRecords(nil); err == nil {
+		t.Errorf("WriteRecords succeeded in a missing directory")
+	}
+}
+
+// assertOnlyFiles fails the test unless dir holds exactly the named entries, so no
+// temporary file was left behind.
+func assertOnlyFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := []string{}
+	for _, entry := range entries {
+		found = append(found, entry.Name())
+	}
+	if !reflect.DeepEqual(found, names) {
+		t.Errorf("%s holds %v, expected %v", dir, found, names)
+	}
+}
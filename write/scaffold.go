@@ -0,0 +1,164 @@
+package write
+
+import (
+	"bytes"
+	"codeleft-cli/types"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// configTemplate renders config.json with "$comment" entries explaining each section.
+// JSON has no comment syntax, so the annotations are plain keys which the CLI ignores.
+var configTemplate = template.Must(template.New("config").Funcs(template.FuncMap{
+	"json": func(v any) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}).Parse(`{
+  "$comment": {{ json .Comment }},
+  "threshold": {{ json .Config.Threshold }},
+  "security": {
+    "$comment": "Security tooling: owasp assesses against the OWASP Top 10, cweTop25 against the CWE Top 25.",
+    "owasp": {{ .Config.Security.Owasp }},
+    "cweTop25": {{ .Config.Security.CweTop25 }}
+  },
+  "quality": {
+    "$comment": "Quality tooling: SOLID principles, PR readiness, clean code, complexity and testability.",
+    "solid": {{ .Config.Quality.Solid }},
+    "cleanCode": {{ .Config.Quality.CleanCode }},
+    "prReady": {{ .Config.Quality.PrReady }},
+    "complexity": {{ .Config.Quality.Complexity }},
+    "complexityPro": {{ .Config.Quality.ComplexityPro }},
+    "testability": {{ .Config.Quality.Testability }}
+  },
+  "safetyCritical": {
+    "$comment": "Safety-critical coding standards. Only enable these for code that must comply with them.",
+    "misraCpp": {{ .Config.SafetyCritical.MisraCpp }}
+  },
+  "testing": {
+    "functionalCoverage": {{ .Config.Testing.FunctionalCoverage }}
+  },
+  "codeReviewType": {
+    "$comment": "IDE extension settings; the CLI keeps them but does not use them.",
+    "pro": {{ .Config.CodeReviewType.Pro }}
+  },
+  "qualitySentry": {
+    "tdd": {{ .Config.QualitySentry.Tdd }}
+  },
+  "testGeneration": {
+    "active": {{ .Config.TestGeneration.Active }},
+    "override": {{ .Config.TestGeneration.Override }},
+    "language": {{ json .Config.TestGeneration.Language }},
+    "testType": {{ json .Config.TestGeneration.TestType }},
+    "library": {{ json .Config.TestGeneration.Library }}
+  },
+  "ignore": {
+    "$comment": "Files ({\"name\": \"main.go\", \"path\": \"cmd\"}), folder names and gitignore-style patterns (\"**/*_test.go\", \"!keep/this.go\") excluded from grades.",
+    "files": {{ json .Config.Ignore.Files }},
+    "folders": {{ json .Config.Ignore.Folders }},
+    "patterns": {{ json .Config.Ignore.Patterns }}
+  },
+  "sources": {
+    "$comment": "Source file extensions (empty for every supported language) and how files without a grade count: off, zero (0% coverage) or fail.",
+    "extensions": {{ json .Config.Sources.Extensions }},
+    "unassessed": {{ json .Config.Sources.Unassessed }}
+  },
+  "gradeScales": {
+    "$comment": "Custom grade scales (ordered labels, lowest first, or numeric ranges mapped onto letter grades) and the tools that use them. Other tools use the built-in letter scale.",
+    "scales": {},
+    "tools": {}
+  },
+  "coverage": {
+    "$comment": "How a grade turns into coverage: steps (the table below: above the threshold, at it, then one grade below, two below, ...), linear (linearStep points per grade) or capped (the steps, at most 100%).",
+    "strategy": "steps",
+    "steps": [120, 100, 90, 80, 70, 50, 30, 10]
+  },
+  "weighting": {
+    "$comment": "How files count towards average coverage: uniform (all alike), linesOfCode (by non-blank lines in the working tree), tools ({\"OWASP-TOP-10\": 3}, others 1) or paths ([{\"path\": \"internal/core/\", \"weight\": 5}], the last match wins, others 1).",
+    "strategy": "uniform"
+  }
+}
+`))
+
+// Scaffolder creates the .codeLeft directory and its files with default contents.
+// Existing files are only replaced when Force is set.
+type Scaffolder struct {
+	Root  string
+	Force bool
+}
+
+// NewScaffolder creates a Scaffolder for the project rooted at root.
+func NewScaffolder(root string, force bool) *Scaffolder {
+	return &Scaffolder{Root: root, Force: force}
+}
+
+// scaffoldFile is a file Scaffold writes inside .codeLeft.
+type scaffoldFile struct {
+	name    string
+	content []byte
+}
+
+// Scaffold writes config.json (from config) and an empty history.ndjson, and returns the
+// paths it wrote. The detected languages are recorded in the config's top-level comment.
+// Without Force nothing is written if either file already exists.
+func (s *Scaffolder) Scaffold(config *types.Config, languages []string) ([]string, error) {
+	written := []string{}
+	codeleftPath := filepath.Join(s.Root, ".codeLeft")
+
+	configContent, err := renderConfig(config, languages)
+	if err != nil {
+		return written, err
+	}
+	files := []scaffoldFile{
+		{name: "config.json", content: configContent},
+		{name: "history.ndjson", content: []byte{}},
+	}
+
+	if !s.Force {
+		existing := []string{}
+		for _, file := range files {
+			path := filepath.Join(codeleftPath, file.name)
+			if _, err := os.Stat(path); err == nil {
+				existing = append(existing, path)
+			}
+		}
+		if len(existing) > 0 {
+			return written, fmt.Errorf("refusing to overwrite %s (use -force to replace)", strings.Join(existing, ", "))
+		}
+	}
+
+	if err := os.MkdirAll(codeleftPath, 0755); err != nil {
+		return written, fmt.Errorf("failed to create %s: %w", codeleftPath, err)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(codeleftPath, file.name)
+		if err := os.WriteFile(path, file.content, 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// renderConfig renders the commented config.json for config.
+func renderConfig(config *types.Config, languages []string) ([]byte, error) {
+	comment := "CodeLeft configuration."
+	if len(languages) > 0 {
+		comment += " Defaults chosen for the detected languages: " + strings.Join(languages, ", ") + "."
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Comment string
+		Config  *types.Config
+	}{Comment: comment, Config: config}
+	if err := configTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render config.json: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package write
+
+import (
+	"codeleft-cli/read"
+	"codeleft-cli/schema"
+	"codeleft-cli/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScaffolderScaffold(t *testing.T) {
+	root := t.TempDir()
+	config := types.NewDefaultConfigForLanguages([]string{"Go"})
+
+	written, err := NewScaffolder(root, false).Scaffold(config, []string{"Go"})
+	if err != nil {
+		t.Fatalf("Scaffold: %v", err)
+	}
+	configPath := filepath.Join(root, ".codeLeft", "config.json")
+	historyPath := filepath.Join(root, ".codeLeft", "history.ndjson")
+	if len(written) != 2 || written[0] != configPath || written[1] != historyPath {
+		t.Fatalf("wrote %v, expected config.json and history.ndjson", written)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("reading config.json: %v", err)
+	}
+	validator, err := schema.NewConfigValidator()
+	if err != nil {
+		t.Fatalf("NewConfigValidator: %v", err)
+	}
+	if problems := validator.Validate(data); len(problems) > 0 {
+		t.Errorf("the scaffolded config.json does not validate: %+v", problems)
+	}
+	decoded, err := read.DecodeConfig(data)
+	if err != nil {
+		t.Fatalf("DecodeConfig: %v", err)
+	}
+	if decoded.Threshold != config.Threshold || !reflect.DeepEqual(decoded.EnabledTools(), config.EnabledTools()) {
+		t.Errorf("decoded threshold %q and tools %v, expected %q and %v", decoded.Threshold, decoded.EnabledTools(), config.Threshold, config.EnabledTools())
+	}
+	if !strings.Contains(string(data), "Defaults chosen for the detected languages: Go.") {
+		t.Errorf("config.json does not name the detected languages:\n%s", data)
+	}
+
+	history, err := os.ReadFile(historyPath)
+	if err != nil || len(history) != 0 {
+		t.Errorf("history.ndjson = %q (%v), expected an empty file", history, err)
+	}
+}
+
+func TestScaffolderKeepsExistingFiles(t *testing.T) {
+	root := t.TempDir()
+	historyPath := filepath.Join(root, ".codeLeft", "history.ndjson")
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(historyPath, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := NewScaffolder(root, false).Scaffold(types.NewDefaultConfig(), nil)
+	if err == nil || len(written) != 0 {
+		t.Fatalf("wrote %v with error %v, expected a refusal to overwrite", written, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".codeLeft", "config.json")); !os.IsNotExist(err) {
+		t.Errorf("config.json was written although history.ndjson exists")
+	}
+
+	if _, err := NewScaffolder(root, true).Scaffold(types.NewDefaultConfig(), nil); err != nil {
+		t.Fatalf("Scaffold with force: %v", err)
+	}
+	if history, _ := os.ReadFile(historyPath); len(history) != 0 {
+		t.Errorf("history.ndjson = %q, expected -force to replace it", history)
+	}
+}